@@ -0,0 +1,345 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+// Link represents a report value linking to a resource
+type Link struct {
+	URL  string `json:"url"`
+	Text string `json:"text"`
+}
+
+// Links represents a report value linking to multiple resources
+type Links []Link
+
+// Texts returns the texts of the links joined by the separator
+func (l Links) Texts(sep string) string {
+	texts := make([]string, len(l))
+
+	for n, k := range l {
+		texts[n] = k.Text
+	}
+
+	return strings.Join(texts, sep)
+}
+
+// URLs returns the URLs of the links joined by the separator
+func (l Links) URLs(sep string) string {
+	urls := make([]string, len(l))
+
+	for n, k := range l {
+		urls[n] = k.URL
+	}
+
+	return strings.Join(urls, sep)
+}
+
+// singleLink returns the Link of the Links values with only one link, so that they are rendered as a Link
+func singleLink(v interface{}) interface{} {
+	if l, ok := v.(Links); ok && len(l) == 1 {
+		return l[0]
+	}
+
+	return v
+}
+
+// ColumnType represents the type of the values of a report column
+type ColumnType int
+
+const (
+	// ColumnText is used for columns with string values
+	ColumnText ColumnType = iota
+
+	// ColumnLink is used for columns with Link or Links values
+	ColumnLink
+
+	// ColumnBallot is used for columns with bool values
+	ColumnBallot
+
+	// ColumnProgress is used for columns with jira.Progress values
+	ColumnProgress
+)
+
+// Column represents a report column and the extractor of its values
+type Column struct {
+	Name  string
+	Title string
+	Type  ColumnType
+	Value func(i *jira.Issue, component *string) interface{}
+}
+
+// Columns is the registry of the columns available for the reports
+var Columns = map[string]*Column{}
+
+// DefaultStaleDays is the number of days in the same active status after which an issue is stale
+const DefaultStaleDays = 30
+
+// DefaultColumnNames are the columns used when a profile doesn't specify any
+var DefaultColumnNames = []string{
+	"key", "summary", "parents", "priority", "status", "owner", "qe-assignee", "ready", "stories", "story-points",
+}
+
+func init() {
+	for _, c := range []*Column{
+		{"key", "Key", ColumnLink, func(i *jira.Issue, _ *string) interface{} {
+			return Link{i.Link, i.Key}
+		}},
+		{"summary", "Summary", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return i.Fields.Summary
+		}},
+		{"priority", "Priority", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			if i.Fields.Priority == nil {
+				return ""
+			}
+			return i.Fields.Priority.Name
+		}},
+		{"status", "Status", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return issueStatusName(i)
+		}},
+		{"owner", "Owner", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return i.Owner
+		}},
+		{"qe-assignee", "QE Assignee", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return i.QEAssignee
+		}},
+		{"stories", "Stories", ColumnProgress, func(i *jira.Issue, component *string) interface{} {
+			return componentStories(i, component).Progress()
+		}},
+		{"story-points", "Story Points", ColumnProgress, func(i *jira.Issue, component *string) interface{} {
+			return componentStories(i, component).StoryPointsProgress()
+		}},
+		{"initiative", "Initiative", ColumnLink, func(i *jira.Issue, _ *string) interface{} {
+			if i.Initiative == nil {
+				return Link{}
+			}
+			return Link{i.Initiative.Link, i.Initiative.Fields.Summary}
+		}},
+		{"subtasks", "Sub-tasks", ColumnProgress, func(i *jira.Issue, component *string) interface{} {
+			subtasks := jira.NewIssueCollection(0)
+
+			for _, s := range componentStories(i, component) {
+				subtasks = append(subtasks, s.Subtasks...)
+			}
+
+			return subtasks.Progress()
+		}},
+		{"fix-versions", "Fix Versions", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			versions := []string{}
+
+			for _, v := range i.Fields.FixVersions {
+				versions = append(versions, v.Name)
+			}
+
+			return strings.Join(versions, ", ")
+		}},
+		{"labels", "Labels", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return strings.Join(i.Fields.Labels, ", ")
+		}},
+		{"components", "Components", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			components := []string{}
+
+			for _, c := range i.Fields.Components {
+				components = append(components, c.Name)
+			}
+
+			return strings.Join(components, ", ")
+		}},
+		{"type", "Type", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return i.Fields.Type.Name
+		}},
+		{"assignee", "Assignee", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			if i.Fields.Assignee == nil {
+				return ""
+			}
+			return i.Fields.Assignee.Name
+		}},
+		{"design", "Design Doc", ColumnLink, func(i *jira.Issue, _ *string) interface{} {
+			return Link{i.Design, i.Design}
+		}},
+		{"acceptance", "Acceptance Criteria", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return i.Acceptance
+		}},
+		{"planning", "Planning", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return strings.Join(i.Gates[jira.GateSetPlanning], ", ")
+		}},
+		{"lead-time", "Lead Time", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return issueDurationText(i, (*jira.Issue).LeadTime)
+		}},
+		{"cycle-time", "Cycle Time", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return issueDurationText(i, (*jira.Issue).CycleTime)
+		}},
+		{"impediment", "Impediment", ColumnBallot, func(i *jira.Issue, _ *string) interface{} {
+			return i.Impediment
+		}},
+		{"burnup", "Burnup", ColumnText, func(i *jira.Issue, component *string) interface{} {
+			s := newProgressSeries(i.Key, i.Fields.Summary, componentStories(i, component), time.Time{}, time.Now())
+
+			if s == nil {
+				return Burnup{}
+			}
+
+			return Burnup(s.Points)
+		}},
+	} {
+		Columns[c.Name] = c
+	}
+}
+
+// ProfileColumns are the columns whose values depend on the profile (e.g. its gate rules) or on all
+// the issues of the report, the issues are nil when the columns are only validated
+var ProfileColumns = map[string]func(p *SearchProfile, issues jira.IssueCollection) *Column{
+	"ready": func(p *SearchProfile, _ jira.IssueCollection) *Column {
+		rules := p.GateRules()
+
+		return &Column{"ready", "Ready", ColumnBallot, func(i *jira.Issue, _ *string) interface{} {
+			return i.Satisfies(rules.Ready)
+		}}
+	},
+	"committed": func(p *SearchProfile, _ jira.IssueCollection) *Column {
+		rules := p.GateRules()
+
+		return &Column{"committed", "Committed", ColumnBallot, func(i *jira.Issue, _ *string) interface{} {
+			return i.Satisfies(rules.Committed)
+		}}
+	},
+	"readiness": func(p *SearchProfile, _ jira.IssueCollection) *Column {
+		rules := p.GateRules()
+
+		return &Column{"readiness", "Readiness", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return gatesIndicator(i, rules.Ready)
+		}}
+	},
+	"commitment": func(p *SearchProfile, _ jira.IssueCollection) *Column {
+		rules := p.GateRules()
+
+		return &Column{"commitment", "Commitment", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			return gatesIndicator(i, rules.Committed)
+		}}
+	},
+	"days-in-status": func(p *SearchProfile, _ jira.IssueCollection) *Column {
+		staleDays := p.StaleDays
+
+		if staleDays <= 0 {
+			staleDays = DefaultStaleDays
+		}
+
+		return &Column{"days-in-status", "Days in Status", ColumnText, func(i *jira.Issue, _ *string) interface{} {
+			if !i.HasHistory() {
+				return "\u2014" // UTF-8 Dash
+			}
+
+			days := durationDays(time.Since(i.StatusSince()))
+
+			if i.IsActive() && days > staleDays {
+				return fmt.Sprintf("%d (stale)", days)
+			}
+
+			return strconv.Itoa(days)
+		}}
+	},
+	"forecast":       newForecastColumn,
+	"parents":        newParentsColumn("parents"),
+	"market-problem": newParentsColumn("market-problem"),
+}
+
+// newParentsColumn returns the function creating the column of the parents of the epics, titled as the
+// parent issue type of the profile
+func newParentsColumn(name string) func(p *SearchProfile, issues jira.IssueCollection) *Column {
+	return func(p *SearchProfile, _ jira.IssueCollection) *Column {
+		return &Column{name, p.ParentLinks().Type, ColumnLink, func(i *jira.Issue, _ *string) interface{} {
+			links := make(Links, len(i.Parents))
+
+			for n, k := range i.Parents {
+				links[n] = Link{k.Link, k.Fields.Summary}
+			}
+
+			return links
+		}}
+	}
+}
+
+// FindColumns returns the columns with the specified names, the profile columns are bound to the profile and the issues
+func FindColumns(names []string, p *SearchProfile, issues jira.IssueCollection) ([]*Column, error) {
+	if len(names) == 0 {
+		names = DefaultColumnNames
+	}
+
+	columns := make([]*Column, len(names))
+
+	for n, k := range names {
+		if newColumn, ok := ProfileColumns[k]; ok {
+			columns[n] = newColumn(p, issues)
+			continue
+		}
+
+		c, ok := Columns[k]
+
+		if !ok {
+			return nil, fmt.Errorf("column '%s' not found", k)
+		}
+
+		columns[n] = c
+	}
+
+	return columns, nil
+}
+
+// gatesIndicator returns a compact indicator of the requirements of the rule (e.g. "dev✓ pm✗ qa—"),
+// the requirements satisfied by an exemption are marked with a dash
+func gatesIndicator(i *jira.Issue, rule jira.GateRule) string {
+	indicators := make([]string, len(rule))
+
+	for n, r := range rule {
+		ok, exempt := r.Check(i)
+
+		if exempt {
+			indicators[n] = r.Label + "\u2014" // UTF-8 Dash
+		} else {
+			indicators[n] = r.Label + googleSheetBallot(ok)
+		}
+	}
+
+	return strings.Join(indicators, " ")
+}
+
+// issueDurationText returns the duration in days, or a dash when the issue history is not available
+func issueDurationText(i *jira.Issue, fn func(*jira.Issue) (time.Duration, bool)) string {
+	if !i.HasHistory() {
+		return "\u2014" // UTF-8 Dash
+	}
+
+	d, ok := fn(i)
+
+	if !ok {
+		return "\u2014" // UTF-8 Dash
+	}
+
+	return strconv.Itoa(durationDays(d))
+}
+
+func durationDays(d time.Duration) int {
+	return int(d / (24 * time.Hour))
+}
+
+func componentStories(i *jira.Issue, component *string) jira.IssueCollection {
+	stories := i.LinkedIssues.FilterByFunction(func(i *jira.Issue) bool {
+		return !i.IsExcluded()
+	})
+
+	if component != nil {
+		stories = stories.FilterByFunction(func(i *jira.Issue) bool {
+			if i.HasComponent(*component) {
+				return true
+			}
+			return false
+		})
+	}
+
+	return stories
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+func runProfiles(args []string) error {
+	flags := newFlagSet("profiles", "-c <config-file>")
+
+	flags.StringVar(&commandFlags.Configuration, "c", "", "Configuration file")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	config, err := loadConfiguration()
+
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tJQL")
+
+	for _, p := range config.Profiles {
+		fmt.Fprintf(w, "%s\t%s\n", p.ID, strings.Join(strings.Fields(p.JQL), " "))
+	}
+
+	return w.Flush()
+}
+
+func runFields(args []string) error {
+	flags := newFlagSet("fields", "-c <config-file> [-u <username>]")
+
+	flags.StringVar(&commandFlags.Configuration, "c", "", "Configuration file")
+	flags.StringVar(&commandFlags.Username, "u", "", "Jira username")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	config, err := loadConfiguration()
+
+	if err != nil {
+		return err
+	}
+
+	jiraClient, err := newJiraClient(config)
+
+	if err != nil {
+		return err
+	}
+
+	mapping := map[string][]string{}
+
+	for k, id := range jiraClient.CustomFields() {
+		if id != "" {
+			mapping[id] = append(mapping[id], k)
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCUSTOM\tMAPPING")
+
+	for _, f := range jiraClient.FieldList {
+		sort.Strings(mapping[f.ID])
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\n", f.ID, f.Name, f.Custom, strings.Join(mapping[f.ID], ", "))
+	}
+
+	return w.Flush()
+}
+
+func runValidate(args []string) error {
+	flags := newFlagSet("validate", "-c <config-file> [-u <username>] [-p <profile-id>]")
+
+	flags.StringVar(&commandFlags.Configuration, "c", "", "Configuration file")
+	flags.StringVar(&commandFlags.Username, "u", "", "Jira username")
+	flags.StringVar(&commandFlags.Profile, "p", "", "Search profile (all the profiles when not specified)")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	config, err := loadConfiguration()
+
+	if err != nil {
+		return err
+	}
+
+	if errs := config.Validate(); len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Printf("error: %s\n", e)
+		}
+
+		return configurationError(jira.ErrorList(errs))
+	}
+
+	profiles := config.Profiles
+
+	if commandFlags.Profile != "" {
+		profile, err := loadProfile(config)
+
+		if err != nil {
+			return err
+		}
+
+		profiles = []*SearchProfile{profile}
+	}
+
+	jiraClient, err := newJiraClient(config)
+
+	if err != nil {
+		return err
+	}
+
+	errs := jira.ErrorList{}
+
+	for _, p := range profiles {
+		total, err := jiraClient.ValidateQuery(p.JQL)
+
+		if err != nil {
+			fmt.Printf("error: profile '%s': %s\n", p.ID, err)
+			errs = append(errs, fmt.Errorf("profile '%s': %w", p.ID, err))
+			continue
+		}
+
+		fmt.Printf("ok: profile '%s': %d issues\n", p.ID, total)
+	}
+
+	return errs.ErrorOrNil()
+}
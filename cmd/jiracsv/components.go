@@ -1,9 +1,14 @@
 package main
 
 import (
+	"sort"
+
 	"github.com/simon3z/jiracsv/jira"
 )
 
+// UnassignedComponent is the name used for the issues without components
+const UnassignedComponent = "[UNASSIGNED]"
+
 // ComponentIssues contain issues of the relevant component
 type ComponentIssues struct {
 	Name   string
@@ -40,27 +45,22 @@ func (c *ComponentsCollection) Add(component string, issue ...*jira.Issue) {
 	}
 }
 
+// Issues returns the issues of the relevant component
+func (c *ComponentsCollection) Issues(component string) []*jira.Issue {
+	if item, ok := c.index[component]; ok {
+		return item.Issues
+	}
+
+	return nil
+}
+
 // AddIssues adds all the issues by component
 func (c *ComponentsCollection) AddIssues(issues []*jira.Issue) {
 	for _, i := range issues {
-		components := map[string]bool{}
-
-		for _, c := range i.Fields.Components {
-			components[c.Name] = true
-		}
-
-		for _, j := range i.LinkedIssues {
-			if j.InStatus(jira.IssueStatusObsolete) {
-				continue
-			}
-
-			for _, c := range j.Fields.Components {
-				components[c.Name] = true
-			}
-		}
+		components := issueComponents(i)
 
 		if len(components) > 0 {
-			for k := range components {
+			for _, k := range components {
 				c.Add(k, i)
 			}
 		} else {
@@ -68,3 +68,32 @@ func (c *ComponentsCollection) AddIssues(issues []*jira.Issue) {
 		}
 	}
 }
+
+// issueComponents returns the sorted components of the issue and of its linked issues
+func issueComponents(i *jira.Issue) []string {
+	components := map[string]bool{}
+
+	for _, c := range i.Fields.Components {
+		components[c.Name] = true
+	}
+
+	for _, j := range i.LinkedIssues {
+		if j.IsExcluded() {
+			continue
+		}
+
+		for _, c := range j.Fields.Components {
+			components[c.Name] = true
+		}
+	}
+
+	names := make([]string, 0, len(components))
+
+	for k := range components {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"fmt"
 	"io/ioutil"
+	"math"
+	"strings"
 
+	"github.com/simon3z/jiracsv/jira"
 	"gopkg.in/yaml.v2"
 )
 
@@ -14,16 +18,217 @@ type SearchProfile struct {
 		Include []string
 		Exclude []string
 	}
+	Columns   []string
+	Ready     []string
+	Committed []string
+	Workflow  map[string]string
+	GroupBy   string `yaml:"group-by"`
+	StaleDays int    `yaml:"stale-days"`
+	Forecast  struct {
+		Weeks int
+	}
+	Parents struct {
+		Type      string
+		Link      string
+		Recursive *bool
+	}
+	Sheet struct {
+		Spreadsheet string
+		Tab         string
+	}
+	rules    *GateRules
+	workflow jira.Workflow
+}
+
+// GateRules are the rules defining when the issues of a profile are ready and committed
+type GateRules struct {
+	Ready     jira.GateRule
+	Committed jira.GateRule
+}
+
+// GateRules returns the gate rules of the profile, the jira default rules are used when not specified
+func (p *SearchProfile) GateRules() *GateRules {
+	if p.rules == nil {
+		return &GateRules{jira.DefaultReadyRule, jira.DefaultCommittedRule}
+	}
+
+	return p.rules
+}
+
+// FindColumns returns the columns of the profile for the issues of the report
+func (p *SearchProfile) FindColumns(issues jira.IssueCollection) ([]*Column, error) {
+	return FindColumns(p.Columns, p, issues)
+}
+
+// ParentLinks returns how the parents of the epics are resolved, the jira defaults are used when not specified
+func (p *SearchProfile) ParentLinks() *jira.ParentLinks {
+	links := *jira.DefaultParentLinks
+
+	if p.Parents.Type != "" {
+		links.Type = p.Parents.Type
+	}
+
+	if p.Parents.Link != "" {
+		links.Link = p.Parents.Link
+	}
+
+	if p.Parents.Recursive != nil {
+		links.Recursive = *p.Parents.Recursive
+	}
+
+	return &links
+}
+
+// StatusWorkflow returns the workflow of the profile, merged with the workflow of the instance
+func (p *SearchProfile) StatusWorkflow() jira.Workflow {
+	return p.workflow
+}
+
+func (p *SearchProfile) parseWorkflow(instance jira.Workflow) error {
+	workflow, err := jira.NewWorkflow(p.Workflow)
+
+	if err != nil {
+		return fmt.Errorf("profile '%s' workflow: %w", p.ID, err)
+	}
+
+	p.workflow = instance.Merge(workflow)
+
+	return nil
+}
+
+func (p *SearchProfile) parseGateRules(gates map[string]*jira.GateSet) error {
+	rules := p.GateRules()
+
+	if len(p.Ready) > 0 {
+		rule, err := jira.ParseGateRule(p.Ready)
+
+		if err != nil {
+			return fmt.Errorf("profile '%s' ready: %w", p.ID, err)
+		}
+
+		rules.Ready = rule
+	}
+
+	if len(p.Committed) > 0 {
+		rule, err := jira.ParseGateRule(p.Committed)
+
+		if err != nil {
+			return fmt.Errorf("profile '%s' committed: %w", p.ID, err)
+		}
+
+		rules.Committed = rule
+	}
+
+	for _, set := range append(rules.Ready.Sets(), rules.Committed.Sets()...) {
+		if _, ok := gates[set]; !ok && jira.DefaultGateSets[set] == nil {
+			return fmt.Errorf("profile '%s': gate set '%s' not found", p.ID, set)
+		}
+	}
+
+	p.rules = rules
+
+	return nil
+}
+
+// ExcludesComponent returns true if the component is excluded from the profile
+func (p *SearchProfile) ExcludesComponent(component string) bool {
+	for _, c := range p.Components.Exclude {
+		if c == component {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Configuration represents a jira instance with multiple search profiles
 type Configuration struct {
 	Instance struct {
-		URL string
+		URL         string
+		Token       string
+		Concurrency int
+		BatchSize   int      `yaml:"batch-size"`
+		PageSize    int      `yaml:"page-size"`
+		ExtraFields []string `yaml:"extra-fields"`
+		RateLimit   float64  `yaml:"rate-limit"`
+		Retries     int
+		Lenient     bool
+		Changelog   bool
+		Hierarchy   bool
+		Links       string
+		OAuth       *struct {
+			ConsumerKey    string `yaml:"consumer-key"`
+			PrivateKeyFile string `yaml:"private-key-file"`
+			AccessToken    string `yaml:"access-token"`
+		}
+	}
+	Fields   map[string]string
+	Gates    map[string]*jira.GateSet
+	Workflow map[string]string
+	Google   struct {
+		Credentials string
+		URL         string
 	}
 	Profiles []*SearchProfile
 }
 
+// ClientOptions returns the Jira client options of the configuration
+func (c *Configuration) ClientOptions() *jira.ClientOptions {
+	return &jira.ClientOptions{
+		Fields:      c.Fields,
+		Concurrency: c.Instance.Concurrency,
+		BatchSize:   c.Instance.BatchSize,
+		PageSize:    c.Instance.PageSize,
+		ExtraFields: c.Instance.ExtraFields,
+		RateLimiter: jira.NewRateLimiter(c.Instance.RateLimit, int(math.Ceil(c.Instance.RateLimit))),
+		MaxRetries:  c.Instance.Retries,
+		Lenient:     c.Instance.Lenient,
+		Changelog:   c.Instance.Changelog,
+		Hierarchy:   c.Instance.Hierarchy,
+		Gates:       c.Gates,
+		Links:       jira.LinksStrategy(c.Instance.Links),
+	}
+}
+
+// Validate returns the problems found in the configuration
+func (c *Configuration) Validate() []error {
+	errs := []error{}
+
+	if c.Instance.URL == "" {
+		errs = append(errs, fmt.Errorf("instance url not specified"))
+	}
+
+	if _, err := jira.ParseLinksStrategy(c.Instance.Links); err != nil {
+		errs = append(errs, fmt.Errorf("instance links: %w", err))
+	}
+
+	ids := map[string]bool{}
+
+	for n, p := range c.Profiles {
+		if p.ID == "" {
+			errs = append(errs, fmt.Errorf("profile #%d: id not specified", n+1))
+		} else if ids[p.ID] {
+			errs = append(errs, fmt.Errorf("profile '%s': duplicate id", p.ID))
+		}
+
+		ids[p.ID] = true
+
+		if strings.TrimSpace(p.JQL) == "" {
+			errs = append(errs, fmt.Errorf("profile '%s': jql not specified", p.ID))
+		}
+
+		if _, err := p.FindColumns(nil); err != nil {
+			errs = append(errs, fmt.Errorf("profile '%s': %w", p.ID, err))
+		}
+
+		if p.GroupBy != "" && p.GroupBy != GroupByComponent && p.GroupBy != GroupByInitiative {
+			errs = append(errs, fmt.Errorf("profile '%s': group-by '%s' not supported", p.ID, p.GroupBy))
+		}
+	}
+
+	return errs
+}
+
 // ReadConfigFile reads a configuration file from the specified path
 func ReadConfigFile(path string) (*Configuration, error) {
 	f, err := ioutil.ReadFile(path)
@@ -40,6 +245,22 @@ func ReadConfigFile(path string) (*Configuration, error) {
 		return nil, err
 	}
 
+	workflow, err := jira.NewWorkflow(c.Workflow)
+
+	if err != nil {
+		return nil, fmt.Errorf("workflow: %w", err)
+	}
+
+	for _, p := range c.Profiles {
+		if err := p.parseGateRules(c.Gates); err != nil {
+			return nil, err
+		}
+
+		if err := p.parseWorkflow(workflow); err != nil {
+			return nil, err
+		}
+	}
+
 	return c, nil
 }
 
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadConfigFileGateSets(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		valid  bool
+	}{
+		{"default sets", "profiles:\n- id: p\n  jql: type = Epic\n  ready: [readiness.dev-ready, planning.no-feature]\n", true},
+		{"custom set", "gates:\n  review:\n    field: Review Gates\nprofiles:\n- id: p\n  jql: type = Epic\n  committed: [review.arch-ok]\n", true},
+		{"ready typo", "profiles:\n- id: p\n  jql: type = Epic\n  ready: [readines.dev-ready]\n", false},
+		{"committed typo", "profiles:\n- id: p\n  jql: type = Epic\n  committed: [comitment.qe-ack]\n", false},
+	}
+
+	dir, err := ioutil.TempDir("", "jiracsv")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	for n, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, string(rune('a'+n))+".yaml")
+
+			if err := ioutil.WriteFile(path, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := ReadConfigFile(path)
+
+			if tt.valid && err != nil {
+				t.Errorf("ReadConfigFile() error = %v, want nil", err)
+			}
+
+			if !tt.valid && err == nil {
+				t.Errorf("ReadConfigFile() error = nil, want gate set not found")
+			}
+		})
+	}
+}
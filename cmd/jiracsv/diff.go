@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+// EpicChanges represents the changes of an epic between two snapshots
+type EpicChanges struct {
+	Key     string
+	Summary string
+	Added   bool
+	Removed bool
+	Changes []string
+}
+
+// ComponentChanges contains the changes of the epics of the relevant component
+type ComponentChanges struct {
+	Name  string
+	Epics []*EpicChanges
+}
+
+// DiffSnapshots returns the changes by component between two snapshots
+func DiffSnapshots(profile *SearchProfile, before, after *jira.Snapshot) []*ComponentChanges {
+	beforeComponents := NewComponentsCollection()
+	afterComponents := NewComponentsCollection()
+
+	for _, c := range profile.Components.Include {
+		beforeComponents.Add(c)
+		afterComponents.Add(c)
+	}
+
+	before.Issues.SetWorkflow(profile.StatusWorkflow(), before.Statuses)
+	after.Issues.SetWorkflow(profile.StatusWorkflow(), after.Statuses)
+
+	beforeComponents.AddIssues(before.Issues)
+	afterComponents.AddIssues(after.Issues)
+
+	for _, k := range beforeComponents.Items {
+		afterComponents.Add(k.Name)
+	}
+
+	rules := profile.GateRules()
+	changes := []*ComponentChanges{}
+
+	for _, k := range afterComponents.Items {
+		if profile.ExcludesComponent(k.Name) {
+			continue
+		}
+
+		if c := diffComponentIssues(rules, k.Name, &k.Name, beforeComponents.Issues(k.Name), k.Issues); c != nil {
+			changes = append(changes, c)
+		}
+	}
+
+	if c := diffComponentIssues(rules, UnassignedComponent, nil, beforeComponents.Orphans, afterComponents.Orphans); c != nil {
+		changes = append(changes, c)
+	}
+
+	return changes
+}
+
+func diffComponentIssues(rules *GateRules, name string, component *string, before, after []*jira.Issue) *ComponentChanges {
+	changes := &ComponentChanges{Name: name}
+	beforeIssues := map[string]*jira.Issue{}
+	afterIssues := map[string]*jira.Issue{}
+
+	for _, i := range before {
+		beforeIssues[i.Key] = i
+	}
+
+	for _, i := range after {
+		afterIssues[i.Key] = i
+
+		b, ok := beforeIssues[i.Key]
+
+		if !ok {
+			changes.Epics = append(changes.Epics, &EpicChanges{Key: i.Key, Summary: i.Fields.Summary, Added: true})
+			continue
+		}
+
+		if c := diffIssues(rules, component, b, i); len(c) > 0 {
+			changes.Epics = append(changes.Epics, &EpicChanges{Key: i.Key, Summary: i.Fields.Summary, Changes: c})
+		}
+	}
+
+	for _, i := range before {
+		if _, ok := afterIssues[i.Key]; !ok {
+			changes.Epics = append(changes.Epics, &EpicChanges{Key: i.Key, Summary: i.Fields.Summary, Removed: true})
+		}
+	}
+
+	if len(changes.Epics) == 0 {
+		return nil
+	}
+
+	return changes
+}
+
+func diffIssues(rules *GateRules, component *string, before, after *jira.Issue) []string {
+	changes := []string{}
+
+	if beforeStatus, afterStatus := issueStatusName(before), issueStatusName(after); beforeStatus != afterStatus {
+		changes = append(changes, fmt.Sprintf("status: %s → %s", beforeStatus, afterStatus))
+	}
+
+	if beforeReady, afterReady := before.Satisfies(rules.Ready), after.Satisfies(rules.Ready); beforeReady != afterReady {
+		changes = append(changes, fmt.Sprintf("ready: %s → %s", googleSheetBallot(beforeReady), googleSheetBallot(afterReady)))
+	}
+
+	beforeStories := componentStories(before, component)
+	afterStories := componentStories(after, component)
+
+	if b, a := beforeStories.StoryPointsProgress(), afterStories.StoryPointsProgress(); b != a {
+		changes = append(changes, fmt.Sprintf("story points: %s → %s (%+d done, %+d total)", storyPointsText(b), storyPointsText(a), a.Status-b.Status, a.Total-b.Total))
+	}
+
+	if !(before.Impediment || beforeStories.AnyImpediment()) && (after.Impediment || afterStories.AnyImpediment()) {
+		changes = append(changes, "new impediment")
+	}
+
+	return changes
+}
+
+func issueStatusName(i *jira.Issue) string {
+	if i.Fields.Status == nil {
+		return ""
+	}
+
+	return i.Fields.Status.Name
+}
+
+func storyPointsText(p jira.Progress) string {
+	if p.Unknown > 0 {
+		return fmt.Sprintf("%d/%d (%d unestimated)", p.Status, p.Total, p.Unknown)
+	}
+
+	return fmt.Sprintf("%d/%d", p.Status, p.Total)
+}
+
+// WriteSnapshotsDiff writes the changes between two snapshots
+func WriteSnapshotsDiff(w io.Writer, before, after *jira.Snapshot, changes []*ComponentChanges) error {
+	if _, err := fmt.Fprintf(w, "# Changes from %s to %s\n", before.Created.Format(time.RFC1123), after.Created.Format(time.RFC1123)); err != nil {
+		return err
+	}
+
+	for _, c := range changes {
+		if _, err := fmt.Fprintf(w, "\n## %s\n\n", c.Name); err != nil {
+			return err
+		}
+
+		for _, e := range c.Epics {
+			mark := "~"
+
+			switch {
+			case e.Added:
+				mark = "+"
+			case e.Removed:
+				mark = "-"
+			}
+
+			if _, err := fmt.Fprintf(w, "%s %s %s\n", mark, e.Key, e.Summary); err != nil {
+				return err
+			}
+
+			for _, d := range e.Changes {
+				if _, err := fmt.Fprintf(w, "    %s\n", d); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func runDiff(args []string) error {
+	flags := newFlagSet("diff", "[-c <config-file> -p <profile-id>] <before-snapshot> <after-snapshot>")
+
+	flags.StringVar(&commandFlags.Configuration, "c", "", "Configuration file")
+	flags.StringVar(&commandFlags.Profile, "p", "", "Search profile")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return usageError("two snapshot files required")
+	}
+
+	profile := &SearchProfile{}
+
+	if commandFlags.Configuration != "" || commandFlags.Profile != "" {
+		config, err := loadConfiguration()
+
+		if err != nil {
+			return err
+		}
+
+		if profile, err = loadProfile(config); err != nil {
+			return err
+		}
+	}
+
+	before, err := ReadSnapshotFile(flags.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	after, err := ReadSnapshotFile(flags.Arg(1))
+
+	if err != nil {
+		return err
+	}
+
+	return WriteSnapshotsDiff(os.Stdout, before, after, DiffSnapshots(profile, before, after))
+}
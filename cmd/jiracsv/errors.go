@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+// Exit codes returned by the command
+const (
+	// ExitSuccess is returned when the report was completed
+	ExitSuccess = 0
+
+	// ExitFailure is returned for the errors not covered by the other exit codes
+	ExitFailure = 1
+
+	// ExitUsage is returned when the command line arguments are invalid
+	ExitUsage = 2
+
+	// ExitConfiguration is returned when the configuration or the profile are invalid
+	ExitConfiguration = 3
+
+	// ExitAuthentication is returned when the Jira authentication failed
+	ExitAuthentication = 4
+
+	// ExitNetwork is returned when Jira could not be reached or throttled the requests
+	ExitNetwork = 5
+
+	// ExitQuery is returned when Jira rejected a JQL query
+	ExitQuery = 6
+
+	// ExitPartialData is returned when the report was completed with some issues missing data
+	ExitPartialData = 7
+)
+
+// CommandError is an error associated with a specific exit code
+type CommandError struct {
+	Code int
+	Err  error
+}
+
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+func usageError(format string, a ...interface{}) error {
+	return &CommandError{ExitUsage, fmt.Errorf(format, a...)}
+}
+
+func configurationError(err error) error {
+	return &CommandError{ExitConfiguration, err}
+}
+
+// pathError is used for the files and directories given on the command line that cannot be accessed
+func pathError(err error) error {
+	return &CommandError{ExitUsage, err}
+}
+
+func partialDataError(err error) error {
+	return &CommandError{ExitPartialData, err}
+}
+
+func isPartialDataError(err error) bool {
+	var e *CommandError
+	return errors.As(err, &e) && e.Code == ExitPartialData
+}
+
+// ExitCode returns the exit code relevant to the error
+func ExitCode(err error) int {
+	var commandErr *CommandError
+	var urlErr *url.Error
+	var pathErr *os.PathError
+
+	switch {
+	case err == nil:
+		return ExitSuccess
+	case errors.As(err, &commandErr):
+		return commandErr.Code
+	case errors.Is(err, jira.ErrUnknownField), errors.Is(err, jira.ErrFieldNotFound), errors.Is(err, jira.ErrInvalidPrivateKey), errors.Is(err, jira.ErrInvalidLinksStrategy):
+		return ExitConfiguration
+	case errors.Is(err, jira.ErrAuthentication):
+		return ExitAuthentication
+	case errors.Is(err, jira.ErrInvalidQuery):
+		return ExitQuery
+	case errors.Is(err, jira.ErrRateLimited), errors.As(err, &urlErr):
+		return ExitNetwork
+	case errors.As(err, &pathErr):
+		return ExitFailure
+	}
+
+	return ExitFailure
+}
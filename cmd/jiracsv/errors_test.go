@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+func TestExitCode(t *testing.T) {
+	_, missing := os.Open("testdata/missing.json")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitSuccess},
+		{"generic", errors.New("failure"), ExitFailure},
+		{"errno", syscall.ENOENT, ExitFailure},
+		{"path", missing, ExitFailure},
+		{"command line path", pathError(missing), ExitUsage},
+		{"usage", usageError("invalid date '%s'", "x"), ExitUsage},
+		{"configuration", configurationError(errors.New("invalid")), ExitConfiguration},
+		{"authentication", fmt.Errorf("profile 'p': %w", jira.ErrorList{jira.ErrAuthentication}), ExitAuthentication},
+		{"transport", &url.Error{Op: "Get", URL: "https://jira.example.com", Err: syscall.ECONNREFUSED}, ExitNetwork},
+		{"rate limited", fmt.Errorf("%w: slow down", jira.ErrRateLimited), ExitNetwork},
+		{"query", jira.ErrInvalidQuery, ExitQuery},
+		{"partial data", partialDataError(jira.ErrorList{errors.New("epic")}), ExitPartialData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/simon3z/jiracsv/forecast"
+	"github.com/simon3z/jiracsv/jira"
+)
+
+// ForecastDateLayout is the layout of the dates of the forecast column
+const ForecastDateLayout = "2006-01-02"
+
+// newForecastColumn returns the column forecasting the completion of the remaining story points of
+// the epics, based on the throughput of the stories of the same component: the report component or,
+// when the report is not grouped by component, the components of the epic
+func newForecastColumn(p *SearchProfile, issues jira.IssueCollection) *Column {
+	now := time.Now()
+	components := NewComponentsCollection()
+	components.AddIssues(issues)
+
+	forecasters := map[string]*forecast.Forecaster{}
+
+	forecaster := func(names []string) *forecast.Forecaster {
+		key := strings.Join(names, "\n")
+
+		if f, ok := forecasters[key]; ok {
+			return f
+		}
+
+		stories := jira.NewIssueCollection(0)
+		found := map[string]bool{}
+
+		addStories := func(componentIssues []*jira.Issue, component *string) {
+			for _, i := range componentIssues {
+				for _, s := range componentStories(i, component) {
+					if !found[s.Key] {
+						found[s.Key] = true
+						stories = append(stories, s)
+					}
+				}
+			}
+		}
+
+		if len(names) == 0 {
+			addStories(components.Orphans, nil)
+		}
+
+		for n := range names {
+			addStories(components.Issues(names[n]), &names[n])
+		}
+
+		forecasters[key] = forecast.NewForecaster(stories, p.Forecast.Weeks, now)
+
+		return forecasters[key]
+	}
+
+	return &Column{"forecast", "Forecast (P50/P85)", ColumnText, func(i *jira.Issue, component *string) interface{} {
+		progress := componentStories(i, component).StoryPointsProgress()
+
+		if !progressAvailable(progress) {
+			return "\u2014" // UTF-8 Dash
+		}
+
+		names := issueComponents(i)
+
+		if component != nil {
+			names = []string{*component}
+		}
+
+		f, ok := forecaster(names).Forecast(i.Key, progress.Remaining())
+
+		if !ok {
+			return "\u2014" // UTF-8 Dash
+		}
+
+		return forecastText(f, fixVersionReleaseDate(i))
+	}}
+}
+
+// forecastText returns the P50 and P85 completion dates compared with the release date, when available
+func forecastText(f *forecast.Forecast, release time.Time) string {
+	text := fmt.Sprintf("%s / %s", f.P50.Format(ForecastDateLayout), f.P85.Format(ForecastDateLayout))
+
+	if release.IsZero() {
+		return text
+	}
+
+	status := "on track"
+	deadline := release.AddDate(0, 0, 1)
+
+	switch {
+	case !f.P50.Before(deadline):
+		status = "late"
+	case !f.P85.Before(deadline):
+		status = "at risk"
+	}
+
+	return fmt.Sprintf("%s (%s for %s)", text, status, release.Format(ForecastDateLayout))
+}
+
+// fixVersionReleaseDate returns the earliest release date of the fix versions of the issue
+func fixVersionReleaseDate(i *jira.Issue) time.Time {
+	release := time.Time{}
+
+	for _, v := range i.Fields.FixVersions {
+		d, err := time.ParseInLocation(ForecastDateLayout, v.ReleaseDate, time.Local)
+
+		if err != nil {
+			continue
+		}
+
+		if release.IsZero() || d.Before(release) {
+			release = d
+		}
+	}
+
+	return release
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+const (
+	// GroupByComponent groups the report issues by component (default)
+	GroupByComponent = "component"
+
+	// GroupByInitiative groups the report issues by initiative
+	GroupByInitiative = "initiative"
+)
+
+// NoInitiative is the name used for the issues without initiative
+const NoInitiative = "[NO INITIATIVE]"
+
+func writeInitiatives(w ReportWriter, profile *SearchProfile, columns []*Column, issues jira.IssueCollection) error {
+	hierarchy := jira.NewHierarchy(issues)
+
+	for _, n := range hierarchy.Initiatives {
+		if err := w.WriteComponent(initiativeTitle(n)); err != nil {
+			return err
+		}
+
+		if err := writeIssues(w, columns, nil, n.Issues()); err != nil {
+			return err
+		}
+	}
+
+	orphans := jira.NewIssueCollection(0)
+
+	for _, n := range hierarchy.Orphans {
+		orphans = append(orphans, n.Issue)
+	}
+
+	if err := w.WriteComponent(NoInitiative); err != nil {
+		return err
+	}
+
+	return writeIssues(w, columns, nil, orphans)
+}
+
+// initiativeTitle returns the initiative key and summary with the rollup of its issues
+func initiativeTitle(n *jira.HierarchyNode) string {
+	rollup := n.Rollup()
+
+	return fmt.Sprintf("%s %s (issues %s, story points %s)", n.Issue.Key, n.Issue.Fields.Summary, progressText(rollup.Issues), progressText(rollup.StoryPoints))
+}
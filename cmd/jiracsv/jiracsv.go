@@ -1,11 +1,16 @@
 package main
 
 import (
-	"encoding/csv"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/simon3z/jiracsv/jira"
 )
@@ -14,130 +19,438 @@ var commandFlags = struct {
 	Configuration string
 	Profile       string
 	Username      string
+	AllProfiles   bool
+	Output        string
+	OutputDir     string
+	SaveSnapshot  string
+	FromSnapshot  string
 }{}
 
-func init() {
-	flag.StringVar(&commandFlags.Username, "u", "", "Jira username")
-	flag.StringVar(&commandFlags.Configuration, "c", "", "Configuration file")
-	flag.StringVar(&commandFlags.Profile, "p", "", "Search profile")
+// Command represents a jiracsv subcommand
+type Command struct {
+	Name        string
+	Description string
+	Run         func(args []string) error
 }
 
-func writeIssues(w *csv.Writer, component *string, issues []*jira.Issue) {
-	for _, i := range issues {
-		stories := i.LinkedIssues.FilterByFunction(func(i *jira.Issue) bool {
-			if i.Fields.Status != nil && jira.IssueStatus(i.Fields.Status.Name) == jira.IssueStatusObsolete {
-				return false
-			}
-			return true
-		})
-
-		if component != nil {
-			stories = stories.FilterByFunction(func(i *jira.Issue) bool {
-				if i.HasComponent(*component) {
-					return true
-				}
-				return false
-			})
-		}
+var commands = []*Command{
+	{"export", "Export the issues of a profile (default)", runExport},
+	{"profiles", "List the profiles in the configuration", runProfiles},
+	{"fields", "List the fields of the Jira instance", runFields},
+	{"validate", "Validate the configuration and the JQL of the profiles", runValidate},
+	{"diff", "Compare two snapshots", runDiff},
+	{"series", "Export the daily story points series of the epics or components", runSeries},
+}
 
-		storiesProgress := stories.Progress()
-		storyPointsProgress := stories.StoryPointsProgress()
+func newFlagSet(name, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
 
-		w.Write([]string{
-			googleSheetLink(i.Link, i.Key),
-			i.Fields.Summary,
-			googleSheetLink(jiraIssueMarketProblemLink(i)),
-			i.Fields.Priority.Name,
-			i.Fields.Status.Name,
-			i.Owner,
-			i.QEAssignee,
-			googleSheetBallot(i.Ready()),
-			googleSheetProgressBar(storiesProgress.Status, storiesProgress.Total),
-			googleSheetStoryPointsBar(storyPointsProgress.Status, storyPointsProgress.Total, storyPointsProgress.Unknown == 0),
-		})
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s %s %s\n", os.Args[0], name, usage)
+		flags.PrintDefaults()
 	}
+
+	return flags
 }
 
-func main() {
-	flag.Parse()
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
 
-	if commandFlags.Configuration == "" {
-		panic("configuration file not specified")
+		return usageError("%s", err)
 	}
 
-	if commandFlags.Profile == "" {
-		panic("profile id file not specified")
+	return nil
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s [command] [flags]\n\nCommands:\n", os.Args[0])
+
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.Name, c.Description)
 	}
 
-	if commandFlags.Username == "" {
-		panic("jira username not specified")
+	fmt.Fprintf(w, "\nRun '%s <command> -h' for the flags of each command.\n", os.Args[0])
+}
+
+func loadConfiguration() (*Configuration, error) {
+	if commandFlags.Configuration == "" {
+		return nil, usageError("configuration file not specified")
 	}
 
 	config, err := ReadConfigFile(commandFlags.Configuration)
 
 	if err != nil {
-		panic(err)
+		return nil, configurationError(err)
+	}
+
+	return config, nil
+}
+
+func loadProfile(config *Configuration) (*SearchProfile, error) {
+	if commandFlags.Profile == "" {
+		return nil, usageError("profile id not specified")
 	}
 
 	profile := config.FindProfile(commandFlags.Profile)
 
 	if profile == nil {
-		panic(fmt.Errorf("profile '%s' not found", commandFlags.Profile))
+		return nil, configurationError(fmt.Errorf("profile '%s' not found", commandFlags.Profile))
 	}
 
-	password, err := GetPassword("PASSWORD", true)
+	return profile, nil
+}
+
+func loadProfiles(config *Configuration) ([]*SearchProfile, error) {
+	if commandFlags.AllProfiles {
+		if commandFlags.Profile != "" {
+			return nil, usageError("profile ids and all profiles are mutually exclusive")
+		}
+
+		return config.Profiles, nil
+	}
+
+	if commandFlags.Profile == "" {
+		return nil, usageError("profile id not specified")
+	}
+
+	profiles := []*SearchProfile{}
+
+	for _, id := range strings.Split(commandFlags.Profile, ",") {
+		profile := config.FindProfile(strings.TrimSpace(id))
+
+		if profile == nil {
+			return nil, configurationError(fmt.Errorf("profile '%s' not found", id))
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+func newJiraClient(config *Configuration) (*jira.Client, error) {
+	credentials, err := GetCredentials(config)
 
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	return jira.NewClient(config.Instance.URL, credentials, config.ClientOptions())
+}
+
+func writeIssues(w ReportWriter, columns []*Column, component *string, issues []*jira.Issue) error {
+	for _, i := range issues {
+		values := make([]interface{}, len(columns))
+
+		for n, c := range columns {
+			values[n] = c.Value(i, component)
+		}
+
+		if err := w.WriteIssue(values); err != nil {
+			return err
+		}
 	}
 
-	jiraClient, err := jira.NewClient(config.Instance.URL, &commandFlags.Username, &password)
+	return nil
+}
+
+func fetchIssues(jiraClient *jira.Client, profile *SearchProfile) (jira.IssueCollection, error) {
+	log.Printf("Profile %s: JQL = %s\n", profile.ID, profile.JQL)
+	issues, err := jiraClient.FindEpics(profile.JQL, profile.ParentLinks())
+	log.Printf("Profile %s: JQL returned issues: %d", profile.ID, len(issues))
 
 	if err != nil {
-		panic(err)
+		if _, ok := err.(jira.ErrorList); !ok || issues == nil {
+			return nil, err
+		}
+
+		if errors.Is(err, jira.ErrAuthentication) || errors.Is(err, jira.ErrRateLimited) {
+			return nil, err
+		}
+
+		err = partialDataError(err)
 	}
 
-	w := csv.NewWriter(os.Stdout)
-	w.Comma = '\t'
+	if commandFlags.SaveSnapshot != "" {
+		snapshot := jira.NewSnapshot(profile.JQL, issues, jiraClient.Statuses)
+		snapshotPath := SnapshotFilePath(commandFlags.SaveSnapshot, profile.ID, snapshot.Created)
 
-	componentIssues := NewComponentsCollection()
+		if err := WriteSnapshotFile(snapshotPath, snapshot); err != nil {
+			return nil, err
+		}
 
-	for _, c := range profile.Components.Include {
-		componentIssues.Add(c)
+		log.Printf("Profile %s: snapshot saved to %s", profile.ID, snapshotPath)
 	}
 
-	log.Printf("JQL = %s\n", profile.JQL)
-	issues, err := jiraClient.FindEpics(profile.JQL)
-	log.Printf("JQL returned issues: %d", len(issues))
+	return issues, err
+}
+
+func readSnapshotIssues(path string) (jira.IssueCollection, jira.Workflow, error) {
+	snapshot, err := ReadSnapshotFile(path)
 
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
-	componentIssues.AddIssues(issues)
+	log.Printf("Snapshot of %s, JQL = %s", snapshot.Created.Format(time.RFC3339), snapshot.JQL)
+	log.Printf("Snapshot issues: %d", len(snapshot.Issues))
 
-	for _, k := range componentIssues.Items {
-		skipComponent := false
+	return snapshot.Issues, snapshot.Statuses, nil
+}
+
+func run(args []string) error {
+	name := "export"
 
-		for _, c := range profile.Components.Exclude {
-			if k.Name == c {
-				skipComponent = true
-				break
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" {
+		printUsage(os.Stdout)
+		return nil
+	}
+
+	for _, c := range commands {
+		if c.Name == name {
+			if err := c.Run(args); err != flag.ErrHelp {
+				return err
 			}
+
+			return nil
+		}
+	}
+
+	printUsage(os.Stderr)
+
+	return usageError("unknown command '%s'", name)
+}
+
+func runExport(args []string) error {
+	flags := newFlagSet("export", "-c <config-file> (-p <profile-id>[,<profile-id>...] | -all-profiles) [-u <username>] [-o <format>] [-d <output-dir>]")
+
+	flags.StringVar(&commandFlags.Username, "u", "", "Jira username")
+	flags.StringVar(&commandFlags.Configuration, "c", "", "Configuration file")
+	flags.StringVar(&commandFlags.Profile, "p", "", "Search profiles (comma separated)")
+	flags.BoolVar(&commandFlags.AllProfiles, "all-profiles", false, "Export all the profiles")
+	flags.StringVar(&commandFlags.Output, "o", "sheets", "Output format ("+strings.Join(OutputFormats, "|")+")")
+	flags.StringVar(&commandFlags.OutputDir, "d", "", "Output directory, each profile is written to a file named after its ID (required for multiple profiles)")
+	flags.StringVar(&commandFlags.SaveSnapshot, "save-snapshot", "", "Save the issues fetched from Jira to a snapshot file (or directory)")
+	flags.StringVar(&commandFlags.FromSnapshot, "from-snapshot", "", "Read the issues from a snapshot file instead of Jira")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	config, err := loadConfiguration()
+
+	if err != nil {
+		return err
+	}
+
+	profiles, err := loadProfiles(config)
+
+	if err != nil {
+		return err
+	}
+
+	if _, ok := OutputExtensions[commandFlags.Output]; !ok {
+		return usageError("output format '%s' not supported", commandFlags.Output)
+	}
+
+	if len(profiles) > 1 {
+		if commandFlags.OutputDir == "" && commandFlags.Output != "gsheets" {
+			return usageError("output directory required for multiple profiles")
+		}
+
+		if commandFlags.FromSnapshot != "" {
+			return usageError("snapshot replay not supported for multiple profiles")
 		}
 
-		if skipComponent {
+		if s, err := os.Stat(commandFlags.SaveSnapshot); commandFlags.SaveSnapshot != "" && (err != nil || !s.IsDir()) {
+			return usageError("snapshot directory required for multiple profiles")
+		}
+	}
+
+	for _, p := range profiles {
+		if _, err := p.FindColumns(nil); err != nil {
+			return configurationError(fmt.Errorf("profile '%s': %w", p.ID, err))
+		}
+	}
+
+	if commandFlags.OutputDir != "" {
+		if err := os.MkdirAll(commandFlags.OutputDir, 0755); err != nil {
+			return pathError(err)
+		}
+	}
+
+	var jiraClient *jira.Client
+
+	if commandFlags.FromSnapshot == "" {
+		if jiraClient, err = newJiraClient(config); err != nil {
+			return err
+		}
+	}
+
+	errs := make(chan error, len(profiles))
+	wg := sync.WaitGroup{}
+
+	for _, p := range profiles {
+		wg.Add(1)
+
+		go func(p *SearchProfile) {
+			defer wg.Done()
+
+			if err := exportProfile(config, jiraClient, p); err != nil {
+				errs <- fmt.Errorf("profile '%s': %w", p.ID, err)
+			}
+		}(p)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if jiraClient != nil {
+		for _, w := range jiraClient.Warnings() {
+			log.Printf("Warning: %s", w)
+		}
+	}
+
+	exportErr := jira.ErrorList{}
+
+	for err := range errs {
+		exportErr = append(exportErr, err)
+	}
+
+	if len(exportErr) == 1 {
+		return exportErr[0]
+	}
+
+	return exportErr.ErrorOrNil()
+}
+
+func newProfileReportWriter(config *Configuration, profile *SearchProfile) (ReportWriter, func(), error) {
+	if commandFlags.Output == "gsheets" {
+		w, err := newProfileGoogleSheetsWriter(config, profile)
+		return w, func() {}, err
+	}
+
+	if commandFlags.OutputDir == "" {
+		w, err := NewReportWriter(commandFlags.Output, os.Stdout)
+		return w, func() {}, err
+	}
+
+	path := filepath.Join(commandFlags.OutputDir, profile.ID+"."+OutputExtensions[commandFlags.Output])
+	f, err := os.Create(path)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Printf("Profile %s: writing %s", profile.ID, path)
+
+	w, err := NewReportWriter(commandFlags.Output, f)
+
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return w, func() { f.Close() }, nil
+}
+
+func exportProfile(config *Configuration, jiraClient *jira.Client, profile *SearchProfile) error {
+	var issues jira.IssueCollection
+	var statuses jira.Workflow
+	var fetchErr error
+
+	if commandFlags.FromSnapshot != "" {
+		issues, statuses, fetchErr = readSnapshotIssues(commandFlags.FromSnapshot)
+	} else {
+		issues, fetchErr = fetchIssues(jiraClient, profile)
+		statuses = jiraClient.Statuses
+	}
+
+	if fetchErr != nil && !isPartialDataError(fetchErr) {
+		return fetchErr
+	}
+
+	issues.SetWorkflow(profile.StatusWorkflow(), statuses)
+
+	columns, err := profile.FindColumns(issues)
+
+	if err != nil {
+		return configurationError(err)
+	}
+
+	w, closeOutput, err := newProfileReportWriter(config, profile)
+
+	if err != nil {
+		return err
+	}
+
+	defer closeOutput()
+
+	if err := writeReport(w, profile, columns, issues); err != nil {
+		return err
+	}
+
+	return fetchErr
+}
+
+func writeReport(w ReportWriter, profile *SearchProfile, columns []*Column, issues jira.IssueCollection) error {
+	if err := w.WriteHeader(columns); err != nil {
+		return err
+	}
+
+	writeGroups := writeComponents
+
+	if profile.GroupBy == GroupByInitiative {
+		writeGroups = writeInitiatives
+	}
+
+	if err := writeGroups(w, profile, columns, issues); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+func writeComponents(w ReportWriter, profile *SearchProfile, columns []*Column, issues jira.IssueCollection) error {
+	componentIssues := NewComponentsCollection()
+
+	for _, c := range profile.Components.Include {
+		componentIssues.Add(c)
+	}
+
+	componentIssues.AddIssues(issues)
+
+	for _, k := range componentIssues.Items {
+		if profile.ExcludesComponent(k.Name) {
 			continue
 		}
 
-		w.Write([]string{k.Name})
-		writeIssues(w, &k.Name, k.Issues)
+		if err := w.WriteComponent(k.Name); err != nil {
+			return err
+		}
 
-		w.Flush()
+		if err := writeIssues(w, columns, &k.Name, k.Issues); err != nil {
+			return err
+		}
 	}
 
-	w.Write([]string{"[UNASSIGNED]"})
-	writeIssues(w, nil, componentIssues.Orphans)
+	if err := w.WriteComponent(UnassignedComponent); err != nil {
+		return err
+	}
+
+	return writeIssues(w, columns, nil, componentIssues.Orphans)
+}
 
-	w.Flush()
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		log.Printf("Error: %s", err)
+		os.Exit(ExitCode(err))
+	}
 }
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+// ReportWriter writes the report rows in a specific output format
+type ReportWriter interface {
+	WriteHeader(columns []*Column) error
+	WriteComponent(name string) error
+	WriteIssue(values []interface{}) error
+	Close() error
+}
+
+// OutputFormats lists the supported output formats
+var OutputFormats = []string{"sheets", "csv", "json", "markdown", "html", "xlsx", "gsheets"}
+
+// OutputExtensions are the file extensions used for the output formats
+var OutputExtensions = map[string]string{
+	"sheets":   "tsv",
+	"csv":      "csv",
+	"json":     "json",
+	"markdown": "md",
+	"html":     "html",
+	"xlsx":     "xlsx",
+	"gsheets":  "",
+}
+
+// NewReportWriter returns a ReportWriter for the specified output format
+func NewReportWriter(format string, w io.Writer) (ReportWriter, error) {
+	switch format {
+	case "sheets":
+		return newSheetsWriter(w), nil
+	case "csv":
+		return newCSVWriter(w), nil
+	case "json":
+		return newJSONWriter(w), nil
+	case "markdown":
+		return newMarkdownWriter(w), nil
+	case "html":
+		return newHTMLWriter(w), nil
+	case "xlsx":
+		return newXLSXWriter(w), nil
+	}
+
+	return nil, fmt.Errorf("output format '%s' not supported", format)
+}
+
+func progressAvailable(p jira.Progress) bool {
+	return p.Status <= p.Total && (p.Total > 0 || p.Status > 0) && p.Unknown == 0
+}
+
+func progressText(p jira.Progress) string {
+	if !progressAvailable(p) {
+		return "\u2014" // UTF-8 Dash
+	}
+
+	return fmt.Sprintf("%d/%d", p.Status, p.Total)
+}
+
+// linkURL returns the URL of the link when it uses the http or https scheme, so that
+// links to other schemes (e.g. javascript:) are rendered as plain text
+func linkURL(l Link) string {
+	u, err := url.Parse(strings.TrimSpace(l.URL))
+
+	if err != nil || (!strings.EqualFold(u.Scheme, "http") && !strings.EqualFold(u.Scheme, "https")) {
+		return ""
+	}
+
+	return l.URL
+}
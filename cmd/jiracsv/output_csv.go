@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+type csvWriter struct {
+	w         *csv.Writer
+	component string
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) WriteHeader(columns []*Column) error {
+	header := []string{"Component"}
+
+	for _, k := range columns {
+		switch k.Type {
+		case ColumnLink:
+			header = append(header, k.Title, k.Title+" URL")
+		case ColumnProgress:
+			header = append(header, k.Title+" Done", k.Title+" Total", k.Title+" Unknown")
+		default:
+			header = append(header, k.Title)
+		}
+	}
+
+	return c.w.Write(header)
+}
+
+func (c *csvWriter) WriteComponent(name string) error {
+	c.component = name
+	return nil
+}
+
+func (c *csvWriter) WriteIssue(values []interface{}) error {
+	record := []string{googleSheetText(c.component)}
+
+	for _, v := range values {
+		switch v := singleLink(v).(type) {
+		case Link:
+			record = append(record, googleSheetText(v.Text), googleSheetText(v.URL))
+		case Links:
+			record = append(record, googleSheetText(v.Texts("; ")), googleSheetText(v.URLs(" ")))
+		case bool:
+			record = append(record, strconv.FormatBool(v))
+		case jira.Progress:
+			record = append(record, strconv.Itoa(v.Status), strconv.Itoa(v.Total), strconv.Itoa(v.Unknown))
+		default:
+			record = append(record, googleSheetText(fmt.Sprint(v)))
+		}
+	}
+
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
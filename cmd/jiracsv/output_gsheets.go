@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/simon3z/jiracsv/sheets"
+)
+
+type googleSheetsWriter struct {
+	client      *sheets.Client
+	spreadsheet string
+	tab         string
+	rows        [][]interface{}
+	headers     []int
+}
+
+// newGoogleSheetsWriter returns a ReportWriter uploading the rows to the sheet
+// tab of the spreadsheet through the Google Sheets API
+func newGoogleSheetsWriter(client *sheets.Client, spreadsheet, tab string) *googleSheetsWriter {
+	return &googleSheetsWriter{client: client, spreadsheet: spreadsheet, tab: tab}
+}
+
+func newProfileGoogleSheetsWriter(config *Configuration, profile *SearchProfile) (ReportWriter, error) {
+	if config.Google.Credentials == "" {
+		return nil, configurationError(fmt.Errorf("google credentials not specified"))
+	}
+
+	if profile.Sheet.Spreadsheet == "" {
+		return nil, configurationError(fmt.Errorf("profile '%s': spreadsheet not specified", profile.ID))
+	}
+
+	key, err := sheets.ReadServiceAccountKey(config.Google.Credentials)
+
+	if err != nil {
+		return nil, configurationError(err)
+	}
+
+	client := sheets.NewClient(key)
+
+	if config.Google.URL != "" {
+		client.BaseURL = config.Google.URL
+	}
+
+	tab := profile.Sheet.Tab
+
+	if tab == "" {
+		tab = profile.ID
+	}
+
+	return newGoogleSheetsWriter(client, profile.Sheet.Spreadsheet, tab), nil
+}
+
+func (g *googleSheetsWriter) WriteHeader(columns []*Column) error {
+	return nil
+}
+
+func (g *googleSheetsWriter) WriteComponent(name string) error {
+	g.headers = append(g.headers, len(g.rows))
+	g.rows = append(g.rows, []interface{}{googleSheetText(name)})
+
+	return nil
+}
+
+func (g *googleSheetsWriter) WriteIssue(values []interface{}) error {
+	record := googleSheetRecord(values)
+	row := make([]interface{}, len(record))
+
+	for n, r := range record {
+		row[n] = r
+	}
+
+	g.rows = append(g.rows, row)
+
+	return nil
+}
+
+func (g *googleSheetsWriter) Close() error {
+	sheetID, err := g.client.SheetID(g.spreadsheet, g.tab)
+
+	if err != nil {
+		return err
+	}
+
+	if err := g.client.Clear(g.spreadsheet, sheets.Range(g.tab, "")); err != nil {
+		return err
+	}
+
+	if err := g.client.Update(g.spreadsheet, sheets.Range(g.tab, "A1"), g.rows); err != nil {
+		return err
+	}
+
+	requests := []interface{}{sheets.BoldRowsRequest(sheetID, -1, -1, false)}
+
+	for _, h := range g.headers {
+		requests = append(requests, sheets.BoldRowsRequest(sheetID, h, h+1, true))
+	}
+
+	return g.client.BatchUpdate(g.spreadsheet, requests)
+}
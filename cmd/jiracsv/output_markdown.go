@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\n", " ", "\r", "", "[", "\\[", "]", "\\]", "<", "&lt;")
+
+type markdownWriter struct {
+	w       io.Writer
+	columns []*Column
+}
+
+func newMarkdownWriter(w io.Writer) *markdownWriter {
+	return &markdownWriter{w: w}
+}
+
+func (m *markdownWriter) WriteHeader(columns []*Column) error {
+	m.columns = columns
+	return nil
+}
+
+func (m *markdownWriter) WriteComponent(name string) error {
+	titles := make([]string, len(m.columns))
+	separators := make([]string, len(m.columns))
+
+	for n, c := range m.columns {
+		titles[n] = markdownEscaper.Replace(c.Title)
+		separators[n] = "---"
+	}
+
+	_, err := fmt.Fprintf(m.w, "\n## %s\n\n| %s |\n| %s |\n", markdownEscaper.Replace(name), strings.Join(titles, " | "), strings.Join(separators, " | "))
+
+	return err
+}
+
+func (m *markdownWriter) WriteIssue(values []interface{}) error {
+	cells := make([]string, len(values))
+
+	for n, v := range values {
+		switch v := singleLink(v).(type) {
+		case Link:
+			cells[n] = markdownLink(v)
+		case Links:
+			links := make([]string, len(v))
+
+			for k, l := range v {
+				links[k] = markdownLink(l)
+			}
+
+			cells[n] = strings.Join(links, ", ")
+		case bool:
+			cells[n] = googleSheetBallot(v)
+		case jira.Progress:
+			cells[n] = progressText(v)
+		default:
+			cells[n] = markdownEscaper.Replace(fmt.Sprint(v))
+		}
+	}
+
+	_, err := fmt.Fprintf(m.w, "| %s |\n", strings.Join(cells, " | "))
+
+	return err
+}
+
+func (m *markdownWriter) Close() error {
+	return nil
+}
+
+func markdownLink(l Link) string {
+	link := linkURL(l)
+
+	if link == "" {
+		return markdownEscaper.Replace(l.Text)
+	}
+
+	return fmt.Sprintf("[%s](%s)", markdownEscaper.Replace(l.Text), strings.NewReplacer("(", "%28", ")", "%29", " ", "%20").Replace(link))
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+type sheetsWriter struct {
+	w *csv.Writer
+}
+
+func newSheetsWriter(w io.Writer) *sheetsWriter {
+	c := csv.NewWriter(w)
+	c.Comma = '\t'
+
+	return &sheetsWriter{c}
+}
+
+func (s *sheetsWriter) WriteHeader(columns []*Column) error {
+	return nil
+}
+
+func (s *sheetsWriter) WriteComponent(name string) error {
+	s.w.Flush()
+
+	if err := s.w.Error(); err != nil {
+		return err
+	}
+
+	return s.w.Write([]string{googleSheetText(name)})
+}
+
+func (s *sheetsWriter) WriteIssue(values []interface{}) error {
+	return s.w.Write(googleSheetRecord(values))
+}
+
+func (s *sheetsWriter) Close() error {
+	s.w.Flush()
+	return s.w.Error()
+}
+
+func googleSheetRecord(values []interface{}) []string {
+	record := make([]string, len(values))
+
+	for n, v := range values {
+		switch v := singleLink(v).(type) {
+		case Link:
+			record[n] = googleSheetLink(v.URL, v.Text)
+		case Links:
+			links := make([]string, len(v))
+
+			for k, l := range v {
+				links[k] = fmt.Sprintf("%s <%s>", l.Text, l.URL)
+			}
+
+			record[n] = googleSheetText(strings.Join(links, "\n"))
+		case bool:
+			record[n] = googleSheetBallot(v)
+		case jira.Progress:
+			record[n] = googleSheetStoryPointsBar(v.Status, v.Total, v.Unknown == 0)
+		default:
+			record[n] = googleSheetText(fmt.Sprint(v))
+		}
+	}
+
+	return record
+}
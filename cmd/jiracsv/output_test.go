@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+var hostileLinks = []struct {
+	name     string
+	link     Link
+	html     string
+	markdown string
+}{
+	{
+		"https",
+		Link{"https://jira.example.com/browse/PROJ-1", "PROJ-1"},
+		`<a href="https://jira.example.com/browse/PROJ-1">PROJ-1</a>`,
+		"[PROJ-1](https://jira.example.com/browse/PROJ-1)",
+	},
+	{
+		"http",
+		Link{"HTTP://docs.example.com/design (v2)", "Design"},
+		`<a href="HTTP://docs.example.com/design (v2)">Design</a>`,
+		"[Design](HTTP://docs.example.com/design%20%28v2%29)",
+	},
+	{
+		"javascript",
+		Link{"javascript:alert(document.cookie)", "Design"},
+		"Design",
+		"Design",
+	},
+	{
+		"javascript with spaces",
+		Link{" JavaScript:alert(1)", "Design"},
+		"Design",
+		"Design",
+	},
+	{
+		"data",
+		Link{"data:text/html,<script>alert(1)</script>", "<b>Doc</b>"},
+		"&lt;b&gt;Doc&lt;/b&gt;",
+		"&lt;b>Doc&lt;/b>",
+	},
+	{
+		"relative",
+		Link{"//evil.example.com", "Doc"},
+		"Doc",
+		"Doc",
+	},
+	{
+		"empty",
+		Link{"", "Doc"},
+		"Doc",
+		"Doc",
+	},
+}
+
+func TestHTMLLink(t *testing.T) {
+	for _, tt := range hostileLinks {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := htmlLink(tt.link); got != tt.html {
+				t.Errorf("htmlLink(%v) = %q, want %q", tt.link, got, tt.html)
+			}
+		})
+	}
+}
+
+func TestMarkdownLink(t *testing.T) {
+	for _, tt := range hostileLinks {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownLink(tt.link); got != tt.markdown {
+				t.Errorf("markdownLink(%v) = %q, want %q", tt.link, got, tt.markdown)
+			}
+		})
+	}
+}
+
+func TestXLSXLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		link Link
+		want bool
+	}{
+		{"https", Link{"https://docs.example.com/design", "Spec"}, true},
+		{"file", Link{"file:///etc/passwd", "Spec"}, false},
+		{"unc", Link{`\\host\share\design.docx`, "Spec"}, false},
+		{"javascript", Link{"javascript:alert(1)", "Spec"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := newXLSXWriter(nil)
+			x.WriteHeader([]*Column{{Name: "design", Title: "Design Doc", Type: ColumnLink}})
+			x.WriteComponent("Component")
+			x.WriteIssue([]interface{}{tt.link})
+
+			sheet, rels := x.worksheet()
+
+			if got := strings.Contains(sheet, "<hyperlink ") || strings.Contains(rels, "<Relationship "); got != tt.want {
+				t.Errorf("worksheet() hyperlink for %q = %t, want %t", tt.link.URL, got, tt.want)
+			}
+
+			if !strings.Contains(sheet, ">Spec<") {
+				t.Errorf("worksheet() cell text for %q missing", tt.link.URL)
+			}
+		})
+	}
+}
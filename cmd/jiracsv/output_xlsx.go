@@ -0,0 +1,285 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+// xlsx cell styles, see xlsxStyles
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleBold
+	xlsxStyleLink
+	xlsxStylePercent
+	xlsxStyleCenter
+)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Report" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="3">
+<font><sz val="11"/><name val="Calibri"/></font>
+<font><b/><sz val="11"/><name val="Calibri"/></font>
+<font><u/><sz val="11"/><color rgb="FF1155CC"/><name val="Calibri"/></font>
+</fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="5">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+<xf numFmtId="0" fontId="2" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+<xf numFmtId="9" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0" applyAlignment="1"><alignment horizontal="center"/></xf>
+</cellXfs>
+<dxfs count="2">
+<dxf><font><b/><color rgb="FF38761D"/></font></dxf>
+<dxf><font><b/><color rgb="FFCC0000"/></font></dxf>
+</dxfs>
+</styleSheet>`
+
+type xlsxRow struct {
+	header bool
+	values []interface{}
+}
+
+type xlsxLink struct {
+	ref string
+	url string
+}
+
+type xlsxWriter struct {
+	w       io.Writer
+	columns []*Column
+	rows    []*xlsxRow
+}
+
+func newXLSXWriter(w io.Writer) *xlsxWriter {
+	return &xlsxWriter{w: w}
+}
+
+func (x *xlsxWriter) WriteHeader(columns []*Column) error {
+	x.columns = columns
+	return nil
+}
+
+func (x *xlsxWriter) WriteComponent(name string) error {
+	x.rows = append(x.rows, &xlsxRow{true, []interface{}{name}})
+	return nil
+}
+
+func (x *xlsxWriter) WriteIssue(values []interface{}) error {
+	x.rows = append(x.rows, &xlsxRow{false, values})
+	return nil
+}
+
+func (x *xlsxWriter) Close() error {
+	z := zip.NewWriter(x.w)
+
+	sheet, sheetRels := x.worksheet()
+
+	files := []struct {
+		name, content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/sheet1.xml", sheet},
+		{"xl/worksheets/_rels/sheet1.xml.rels", sheetRels},
+	}
+
+	for _, f := range files {
+		fw, err := z.Create(f.name)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return err
+		}
+	}
+
+	return z.Close()
+}
+
+func (x *xlsxWriter) worksheet() (string, string) {
+	b := &strings.Builder{}
+	links := []xlsxLink{}
+	lastRow := len(x.rows) + 1
+
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	b.WriteString(`<sheetPr><outlinePr summaryBelow="0"/></sheetPr>`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	b.WriteString(`<sheetFormatPr defaultRowHeight="15" outlineLevelRow="1"/>`)
+
+	if len(x.columns) > 0 {
+		b.WriteString(`<cols>`)
+
+		for n, c := range x.columns {
+			fmt.Fprintf(b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, n+1, n+1, xlsxColumnWidth(c))
+		}
+
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData><row r="1">`)
+
+	for n, c := range x.columns {
+		xlsxStringCell(b, xlsxCellRef(n, 1), c.Title, xlsxStyleBold)
+	}
+
+	b.WriteString(`</row>`)
+
+	for n, r := range x.rows {
+		rowNumber := n + 2
+
+		if r.header {
+			fmt.Fprintf(b, `<row r="%d">`, rowNumber)
+			xlsxStringCell(b, xlsxCellRef(0, rowNumber), fmt.Sprint(r.values[0]), xlsxStyleBold)
+			b.WriteString(`</row>`)
+			continue
+		}
+
+		fmt.Fprintf(b, `<row r="%d" outlineLevel="1">`, rowNumber)
+
+		for k, v := range r.values {
+			ref := xlsxCellRef(k, rowNumber)
+
+			switch v := singleLink(v).(type) {
+			case Links:
+				xlsxStringCell(b, ref, v.Texts("\n"), xlsxStyleDefault)
+			case Link:
+				link := linkURL(v)
+
+				if link == "" {
+					xlsxStringCell(b, ref, v.Text, xlsxStyleDefault)
+					break
+				}
+
+				links = append(links, xlsxLink{ref, link})
+				xlsxStringCell(b, ref, v.Text, xlsxStyleLink)
+			case bool:
+				xlsxStringCell(b, ref, googleSheetBallot(v), xlsxStyleCenter)
+			case jira.Progress:
+				if !progressAvailable(v) {
+					xlsxStringCell(b, ref, progressText(v), xlsxStyleCenter)
+					break
+				}
+
+				fmt.Fprintf(b, `<c r="%s" s="%d"><v>%g</v></c>`, ref, xlsxStylePercent, v.Percentage())
+			default:
+				xlsxStringCell(b, ref, fmt.Sprint(v), xlsxStyleDefault)
+			}
+		}
+
+		b.WriteString(`</row>`)
+	}
+
+	b.WriteString(`</sheetData>`)
+
+	priority := 1
+
+	for n, c := range x.columns {
+		sqref := fmt.Sprintf("%s:%s", xlsxCellRef(n, 2), xlsxCellRef(n, lastRow))
+
+		switch c.Type {
+		case ColumnProgress:
+			fmt.Fprintf(b, `<conditionalFormatting sqref="%s"><cfRule type="dataBar" priority="%d"><dataBar><cfvo type="num" val="0"/><cfvo type="num" val="1"/><color rgb="FF93C47D"/></dataBar></cfRule></conditionalFormatting>`, sqref, priority)
+			priority++
+		case ColumnBallot:
+			fmt.Fprintf(b, `<conditionalFormatting sqref="%s"><cfRule type="cellIs" dxfId="0" priority="%d" operator="equal"><formula>"%s"</formula></cfRule><cfRule type="cellIs" dxfId="1" priority="%d" operator="equal"><formula>"%s"</formula></cfRule></conditionalFormatting>`, sqref, priority, googleSheetBallot(true), priority+1, googleSheetBallot(false))
+			priority += 2
+		}
+	}
+
+	rels := &strings.Builder{}
+	rels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	rels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	if len(links) > 0 {
+		b.WriteString(`<hyperlinks>`)
+
+		for n, l := range links {
+			fmt.Fprintf(b, `<hyperlink ref="%s" r:id="rId%d"/>`, l.ref, n+1)
+			fmt.Fprintf(rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="%s" TargetMode="External"/>`, n+1, xlsxEscape(l.url))
+		}
+
+		b.WriteString(`</hyperlinks>`)
+	}
+
+	rels.WriteString(`</Relationships>`)
+	b.WriteString(`</worksheet>`)
+
+	return b.String(), rels.String()
+}
+
+func xlsxStringCell(b *strings.Builder, ref, value string, style int) {
+	fmt.Fprintf(b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xlsxEscape(value))
+}
+
+func xlsxEscape(s string) string {
+	b := &strings.Builder{}
+	xml.EscapeText(b, []byte(s))
+
+	return b.String()
+}
+
+func xlsxCellRef(column, row int) string {
+	name := ""
+
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+
+	return fmt.Sprintf("%s%d", name, row)
+}
+
+func xlsxColumnWidth(c *Column) int {
+	switch c.Type {
+	case ColumnBallot:
+		return 8
+	case ColumnProgress:
+		return 14
+	case ColumnLink:
+		return 16
+	}
+
+	if c.Name == "summary" {
+		return 60
+	}
+
+	return 20
+}
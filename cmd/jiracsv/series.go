@@ -0,0 +1,366 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+// SeriesDateLayout is the layout of the dates of the series
+const SeriesDateLayout = "2006-01-02"
+
+// ProgressSeries represents the daily story points progress of an epic or of a component
+type ProgressSeries struct {
+	Name   string
+	Title  string
+	Points []*jira.ProgressPoint
+}
+
+// EpicsSeries returns the story points series of each epic
+func EpicsSeries(issues jira.IssueCollection, since, now time.Time) []*ProgressSeries {
+	series := []*ProgressSeries{}
+
+	for _, i := range issues {
+		if s := newProgressSeries(i.Key, i.Fields.Summary, componentStories(i, nil), since, now); s != nil {
+			series = append(series, s)
+		}
+	}
+
+	return series
+}
+
+// ComponentsSeries returns the story points series of each component of the profile
+func ComponentsSeries(profile *SearchProfile, issues jira.IssueCollection, since, now time.Time) []*ProgressSeries {
+	componentIssues := NewComponentsCollection()
+
+	for _, c := range profile.Components.Include {
+		componentIssues.Add(c)
+	}
+
+	componentIssues.AddIssues(issues)
+
+	series := []*ProgressSeries{}
+
+	for _, k := range componentIssues.Items {
+		if profile.ExcludesComponent(k.Name) {
+			continue
+		}
+
+		stories := jira.NewIssueCollection(0)
+
+		for _, i := range k.Issues {
+			stories = append(stories, componentStories(i, &k.Name)...)
+		}
+
+		if s := newProgressSeries(k.Name, k.Name, stories, since, now); s != nil {
+			series = append(series, s)
+		}
+	}
+
+	stories := jira.NewIssueCollection(0)
+
+	for _, i := range componentIssues.Orphans {
+		stories = append(stories, componentStories(i, nil)...)
+	}
+
+	if s := newProgressSeries(UnassignedComponent, UnassignedComponent, stories, since, now); s != nil {
+		series = append(series, s)
+	}
+
+	return series
+}
+
+func newProgressSeries(name, title string, stories jira.IssueCollection, since, now time.Time) *ProgressSeries {
+	if since.IsZero() {
+		created, ok := stories.Created()
+
+		if !ok {
+			return nil
+		}
+
+		since = created
+	}
+
+	return &ProgressSeries{name, title, stories.StoryPointsSeries(since, now)}
+}
+
+// SeriesFormats lists the supported series output formats
+var SeriesFormats = []string{"csv", "json", "html"}
+
+func seriesFormatSupported(format string) bool {
+	for _, f := range SeriesFormats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteSeries writes the series in the specified output format
+func WriteSeries(w io.Writer, format string, series []*ProgressSeries) error {
+	switch format {
+	case "csv":
+		return writeSeriesCSV(w, series)
+	case "json":
+		return writeSeriesJSON(w, series)
+	case "html":
+		return writeSeriesHTML(w, series)
+	}
+
+	return fmt.Errorf("output format '%s' not supported", format)
+}
+
+func writeSeriesCSV(w io.Writer, series []*ProgressSeries) error {
+	c := csv.NewWriter(w)
+
+	if err := c.Write([]string{"Series", "Date", "Done", "Total", "Unknown"}); err != nil {
+		return err
+	}
+
+	for _, s := range series {
+		for _, p := range s.Points {
+			record := []string{
+				s.Name, p.Date.Format(SeriesDateLayout), strconv.Itoa(p.Status), strconv.Itoa(p.Total), strconv.Itoa(p.Unknown),
+			}
+
+			if err := c.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.Flush()
+
+	return c.Error()
+}
+
+type jsonSeriesPoint struct {
+	Date    string `json:"date"`
+	Done    int    `json:"done"`
+	Total   int    `json:"total"`
+	Unknown int    `json:"unknown"`
+}
+
+type jsonSeries struct {
+	Name   string            `json:"name"`
+	Title  string            `json:"title"`
+	Points []jsonSeriesPoint `json:"points"`
+}
+
+func writeSeriesJSON(w io.Writer, series []*ProgressSeries) error {
+	report := []jsonSeries{}
+
+	for _, s := range series {
+		points := make([]jsonSeriesPoint, len(s.Points))
+
+		for n, p := range s.Points {
+			points[n] = jsonSeriesPoint{p.Date.Format(SeriesDateLayout), p.Status, p.Total, p.Unknown}
+		}
+
+		report = append(report, jsonSeries{s.Name, s.Title, points})
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+
+	return e.Encode(report)
+}
+
+const htmlSeriesTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Jira Burnup</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; margin: 2em; }
+svg { margin-bottom: 2em; }
+svg text { font-size: 11px; fill: #666; }
+.total { fill: none; stroke: #999; stroke-width: 2; }
+.done { fill: none; stroke: #6aa84f; stroke-width: 2; }
+.axis { stroke: #ccc; }
+</style>
+</head>
+<body>
+{{- range .}}
+<h2>{{.Title}}{{if ne .Name .Title}} ({{.Name}}){{end}}</h2>
+{{chart .Points}}
+{{- end}}
+</body>
+</html>
+`
+
+var htmlSeries = template.Must(template.New("series").Funcs(template.FuncMap{"chart": svgBurnupChart}).Parse(htmlSeriesTemplate))
+
+func writeSeriesHTML(w io.Writer, series []*ProgressSeries) error {
+	return htmlSeries.Execute(w, series)
+}
+
+// Burnup represents a report value with the daily story points progress of the stories of an epic
+type Burnup []*jira.ProgressPoint
+
+// String returns the progress of the last day of the burnup
+func (b Burnup) String() string {
+	if len(b) == 0 {
+		return "\u2014" // UTF-8 Dash
+	}
+
+	return progressText(b[len(b)-1].Progress)
+}
+
+// svgBurnupChart returns an SVG chart of the done and total story points of the series
+func svgBurnupChart(points []*jira.ProgressPoint) template.HTML {
+	return svgBurnup(points, 600, 200, 30, true)
+}
+
+// svgBurnupSparkline returns a small SVG chart of the series, without labels, fitting in a report cell
+func svgBurnupSparkline(points []*jira.ProgressPoint) template.HTML {
+	return svgBurnup(points, 160, 40, 4, false)
+}
+
+func svgBurnup(points []*jira.ProgressPoint, width, height, margin int, labels bool) template.HTML {
+	if len(points) == 0 {
+		return ""
+	}
+
+	max := 1
+
+	for _, p := range points {
+		if p.Total > max {
+			max = p.Total
+		}
+	}
+
+	x := func(n int) float64 {
+		if len(points) == 1 {
+			return float64(margin)
+		}
+		return float64(margin) + float64(n)*float64(width-2*margin)/float64(len(points)-1)
+	}
+
+	y := func(v int) float64 {
+		return float64(height-margin) - float64(v)*float64(height-2*margin)/float64(max)
+	}
+
+	total, done := []string{}, []string{}
+
+	for n, p := range points {
+		total = append(total, fmt.Sprintf("%.1f,%.1f", x(n), y(p.Total)))
+		done = append(done, fmt.Sprintf("%.1f,%.1f", x(n), y(p.Status)))
+	}
+
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d">`, width, height)
+	fmt.Fprintf(b, `<line class="axis" x1="%d" y1="%d" x2="%d" y2="%d"/>`, margin, height-margin, width-margin, height-margin)
+	fmt.Fprintf(b, `<line class="axis" x1="%d" y1="%d" x2="%d" y2="%d"/>`, margin, margin, margin, height-margin)
+	fmt.Fprintf(b, `<polyline class="total" points="%s"/>`, strings.Join(total, " "))
+	fmt.Fprintf(b, `<polyline class="done" points="%s"/>`, strings.Join(done, " "))
+
+	if labels {
+		fmt.Fprintf(b, `<text x="%d" y="%d">%d</text>`, 2, margin+4, max)
+		fmt.Fprintf(b, `<text x="%d" y="%d">%s</text>`, margin, height-margin+15, points[0].Date.Format(SeriesDateLayout))
+		fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end">%s</text>`, width-margin, height-margin+15, points[len(points)-1].Date.Format(SeriesDateLayout))
+	} else {
+		fmt.Fprintf(b, `<title>%s</title>`, template.HTMLEscapeString(Burnup(points).String()))
+	}
+
+	fmt.Fprintf(b, `</svg>`)
+
+	return template.HTML(b.String())
+}
+
+func runSeries(args []string) error {
+	flags := newFlagSet("series", "-c <config-file> -p <profile-id> [-u <username>] [-by epic|component] [-o <format>] [-since <date>] [-from-snapshot <file>]")
+
+	by := flags.String("by", "epic", "Group the series by epic or by component")
+	since := flags.String("since", "", "First day of the series (YYYY-MM-DD), the creation of the first story by default")
+
+	flags.StringVar(&commandFlags.Username, "u", "", "Jira username")
+	flags.StringVar(&commandFlags.Configuration, "c", "", "Configuration file")
+	flags.StringVar(&commandFlags.Profile, "p", "", "Search profile")
+	flags.StringVar(&commandFlags.Output, "o", "csv", "Output format ("+strings.Join(SeriesFormats, "|")+")")
+	flags.StringVar(&commandFlags.FromSnapshot, "from-snapshot", "", "Read the issues from a snapshot file instead of Jira")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if !seriesFormatSupported(commandFlags.Output) {
+		return usageError("output format '%s' not supported", commandFlags.Output)
+	}
+
+	if *by != "epic" && *by != "component" {
+		return usageError("series grouping '%s' not supported", *by)
+	}
+
+	sinceTime := time.Time{}
+
+	if *since != "" {
+		t, err := time.ParseInLocation(SeriesDateLayout, *since, time.Local)
+
+		if err != nil {
+			return usageError("invalid date '%s'", *since)
+		}
+
+		sinceTime = t
+	}
+
+	config, err := loadConfiguration()
+
+	if err != nil {
+		return err
+	}
+
+	profile, err := loadProfile(config)
+
+	if err != nil {
+		return err
+	}
+
+	var issues jira.IssueCollection
+	var statuses jira.Workflow
+	var fetchErr error
+
+	if commandFlags.FromSnapshot != "" {
+		issues, statuses, fetchErr = readSnapshotIssues(commandFlags.FromSnapshot)
+	} else {
+		jiraClient, err := newJiraClient(config)
+
+		if err != nil {
+			return err
+		}
+
+		issues, fetchErr = fetchIssues(jiraClient, profile)
+		statuses = jiraClient.Statuses
+	}
+
+	if fetchErr != nil && !isPartialDataError(fetchErr) {
+		return fetchErr
+	}
+
+	issues.SetWorkflow(profile.StatusWorkflow(), statuses)
+
+	var series []*ProgressSeries
+
+	switch *by {
+	case "epic":
+		series = EpicsSeries(issues, sinceTime, time.Now())
+	case "component":
+		series = ComponentsSeries(profile, issues, sinceTime, time.Now())
+	}
+
+	if err := WriteSeries(os.Stdout, commandFlags.Output, series); err != nil {
+		return err
+	}
+
+	return fetchErr
+}
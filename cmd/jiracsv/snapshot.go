@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+// ReadSnapshotFile reads a snapshot from the specified path, given on the command line
+func ReadSnapshotFile(path string) (*jira.Snapshot, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, pathError(err)
+	}
+
+	defer f.Close()
+
+	return jira.ReadSnapshot(f)
+}
+
+// WriteSnapshotFile writes a snapshot to the specified path
+func WriteSnapshotFile(path string, s *jira.Snapshot) error {
+	f, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	if err := jira.WriteSnapshot(f, s); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// SnapshotFilePath returns the path of the snapshot file, when path is a
+// directory the file is named after the profile and the creation time
+func SnapshotFilePath(path, profileID string, created time.Time) string {
+	if s, err := os.Stat(path); err == nil && s.IsDir() {
+		return filepath.Join(path, fmt.Sprintf("%s-%s.json", profileID, created.Format("20060102-150405")))
+	}
+
+	return path
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sort"
 	"strings"
@@ -44,6 +45,47 @@ func GetPassword(env string, interactive bool) (string, error) {
 	return password, nil
 }
 
+// GetCredentials returns the Jira credentials, in order of precedence: the
+// JIRA_TOKEN environment variable, the instance token, the instance OAuth
+// settings and finally the username with the password
+func GetCredentials(config *Configuration) (*jira.Credentials, error) {
+	if token := os.Getenv("JIRA_TOKEN"); token != "" {
+		return &jira.Credentials{Token: token}, nil
+	}
+
+	if config.Instance.Token != "" {
+		return &jira.Credentials{Token: config.Instance.Token}, nil
+	}
+
+	if o := config.Instance.OAuth; o != nil {
+		data, err := ioutil.ReadFile(o.PrivateKeyFile)
+
+		if err != nil {
+			return nil, configurationError(err)
+		}
+
+		key, err := jira.ParseRSAPrivateKey(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &jira.Credentials{OAuth: &jira.OAuthCredentials{ConsumerKey: o.ConsumerKey, PrivateKey: key, AccessToken: o.AccessToken}}, nil
+	}
+
+	if commandFlags.Username == "" {
+		return nil, usageError("jira username not specified")
+	}
+
+	password, err := GetPassword("PASSWORD", true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &jira.Credentials{Username: commandFlags.Username, Password: password}, nil
+}
+
 func sortedIssuesMapKeys(m map[string][]*jira.Issue) []string {
 	keys := make([]string, 0, len(m))
 
@@ -56,15 +98,23 @@ func sortedIssuesMapKeys(m map[string][]*jira.Issue) []string {
 	return keys
 }
 
-func jiraIssueMarketProblemLink(i *jira.Issue) (string, string) {
-	if i.MarketProblem == nil {
-		return "", ""
-	}
-	return i.MarketProblem.Link, i.MarketProblem.Fields.Summary
+func googleSheetLink(link, text string) string {
+	return fmt.Sprintf("=HYPERLINK(%s,%s)", googleSheetString(link), googleSheetString(text))
 }
 
-func googleSheetLink(link, text string) string {
-	return fmt.Sprintf("=HYPERLINK(\"%s\",\"%s\")", link, text)
+// googleSheetString returns a formula string literal, double quotes are escaped by doubling them
+func googleSheetString(s string) string {
+	return "\"" + strings.ReplaceAll(s, "\"", "\"\"") + "\""
+}
+
+// googleSheetText returns a cell value that is never evaluated as a formula, values starting
+// with a formula trigger character are prefixed with an apostrophe to force them as text
+func googleSheetText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
 }
 
 func googleSheetBallot(value bool) string {
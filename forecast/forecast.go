@@ -0,0 +1,161 @@
+package forecast
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/simon3z/jiracsv/jira"
+)
+
+const (
+	// DefaultWeeks is the default number of trailing weeks of throughput used by the forecasts
+	DefaultWeeks = 6
+
+	// DefaultTrials is the default number of trials of the Monte Carlo simulations
+	DefaultTrials = 1000
+
+	// MaxWeeks is the maximum number of weeks simulated by each trial
+	MaxWeeks = 520
+
+	// week is the duration of a throughput sample
+	week = 7 * 24 * time.Hour
+)
+
+// Forecast represents the expected completion of the remaining story points
+type Forecast struct {
+	Remaining int
+	Velocity  float64
+	P50       time.Time
+	P85       time.Time
+}
+
+// Forecaster forecasts the completion of the remaining story points from the trailing throughput of a team
+type Forecaster struct {
+	Weeks      int
+	Trials     int
+	Throughput []int
+	Now        time.Time
+}
+
+// NewForecaster creates and returns a new Forecaster based on the weekly throughput of the team stories
+func NewForecaster(stories jira.IssueCollection, weeks int, now time.Time) *Forecaster {
+	if weeks <= 0 {
+		weeks = DefaultWeeks
+	}
+
+	return &Forecaster{
+		Weeks:      weeks,
+		Trials:     DefaultTrials,
+		Throughput: WeeklyThroughput(stories, weeks, now),
+		Now:        now,
+	}
+}
+
+// WeeklyThroughput returns the story points resolved in each of the weeks preceding now, the oldest first
+func WeeklyThroughput(stories jira.IssueCollection, weeks int, now time.Time) []int {
+	throughput := make([]int, weeks)
+	start := now.Add(-time.Duration(weeks) * week)
+
+	for _, i := range stories {
+		if !i.IsType(jira.IssueTypeStory) || i.IsExcluded() || !i.HasStoryPoints() {
+			continue
+		}
+
+		resolved, ok := i.ResolvedTime()
+
+		if !ok || resolved.Before(start) || resolved.After(now) {
+			continue
+		}
+
+		n := int(resolved.Sub(start) / week)
+
+		if n >= weeks {
+			n = weeks - 1
+		}
+
+		throughput[n] += i.StoryPoints
+	}
+
+	return throughput
+}
+
+// Velocity returns the average weekly throughput
+func (f *Forecaster) Velocity() float64 {
+	total := 0
+
+	for _, t := range f.Throughput {
+		total += t
+	}
+
+	return float64(total) / float64(len(f.Throughput))
+}
+
+// Forecast runs a Monte Carlo simulation sampling the weekly throughput to complete the remaining
+// story points, ok is false when there is no throughput to forecast from. The simulation is seeded
+// with the key of the issue so that the same history returns the same forecast for each issue,
+// regardless of the other forecasts
+func (f *Forecaster) Forecast(key string, remaining int) (*Forecast, bool) {
+	velocity := f.Velocity()
+
+	if velocity == 0 {
+		return nil, false
+	}
+
+	if remaining <= 0 {
+		return &Forecast{remaining, velocity, f.Now, f.Now}, true
+	}
+
+	r := rand.New(rand.NewSource(seed(key)))
+	trials := make([]float64, f.Trials)
+
+	for n := range trials {
+		trials[n] = f.trial(r, remaining)
+	}
+
+	sort.Float64s(trials)
+
+	return &Forecast{
+		Remaining: remaining,
+		Velocity:  velocity,
+		P50:       f.Now.Add(time.Duration(percentile(trials, 0.50) * float64(week))),
+		P85:       f.Now.Add(time.Duration(percentile(trials, 0.85) * float64(week))),
+	}, true
+}
+
+// trial returns the number of weeks (with the fraction of the last one) needed to complete the remaining story points
+func (f *Forecaster) trial(r *rand.Rand, remaining int) float64 {
+	done := 0
+
+	for weeks := 0; weeks < MaxWeeks; weeks++ {
+		sample := f.Throughput[r.Intn(len(f.Throughput))]
+
+		if done+sample >= remaining {
+			return float64(weeks) + float64(remaining-done)/float64(sample)
+		}
+
+		done += sample
+	}
+
+	return MaxWeeks
+}
+
+// seed returns the seed of the simulations of the issue key
+func seed(key string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+
+	return int64(h.Sum64())
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	n := int(math.Ceil(p*float64(len(sorted)))) - 1
+
+	if n < 0 {
+		n = 0
+	}
+
+	return sorted[n]
+}
@@ -0,0 +1,228 @@
+package jira
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// Credentials represents the credentials used to authenticate to Jira, the
+// Token and OAuth credentials take precedence over Username and Password
+type Credentials struct {
+	Username string
+	Password string
+	Token    string
+	OAuth    *OAuthCredentials
+}
+
+// OAuthCredentials represents the credentials of an OAuth 1.0a application link
+type OAuthCredentials struct {
+	ConsumerKey string
+	PrivateKey  *rsa.PrivateKey
+	AccessToken string
+}
+
+// Transport returns the http transport authenticating with the credentials
+// on top of the base transport (http.DefaultTransport when nil)
+func (c *Credentials) Transport(base http.RoundTripper) http.RoundTripper {
+	switch {
+	case c == nil:
+		break
+	case c.Token != "":
+		return &BearerAuthTransport{Token: c.Token, Transport: base}
+	case c.OAuth != nil:
+		return &OAuthTransport{Credentials: *c.OAuth, Transport: base}
+	case c.Username != "":
+		return &jira.BasicAuthTransport{Username: c.Username, Password: c.Password, Transport: base}
+	}
+
+	if base == nil {
+		return http.DefaultTransport
+	}
+
+	return base
+}
+
+// BearerAuthTransport is an http.RoundTripper that authenticates all requests
+// using a bearer token (e.g. a Jira Personal Access Token)
+type BearerAuthTransport struct {
+	Token     string
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *BearerAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req2 := req.Clone(req.Context())
+	req2.Header.Set("Authorization", "Bearer "+t.Token)
+
+	return t.transport().RoundTrip(req2)
+}
+
+// Client returns an *http.Client that makes requests authenticated with the bearer token
+func (t *BearerAuthTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+func (t *BearerAuthTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+
+	return http.DefaultTransport
+}
+
+// OAuthTransport is an http.RoundTripper that signs all requests using
+// OAuth 1.0a with the RSA-SHA1 signature method
+type OAuthTransport struct {
+	Credentials OAuthCredentials
+	Transport   http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *OAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	nonce := make([]byte, 16)
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{
+		"oauth_consumer_key":     t.Credentials.ConsumerKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": "RSA-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_token":            t.Credentials.AccessToken,
+		"oauth_version":          "1.0",
+	}
+
+	signature, err := oauthSignature(req, params, t.Credentials.PrivateKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params["oauth_signature"] = signature
+
+	header := make([]string, 0, len(params))
+
+	for k, v := range params {
+		header = append(header, fmt.Sprintf("%s=\"%s\"", oauthEscape(k), oauthEscape(v)))
+	}
+
+	sort.Strings(header)
+
+	req2 := req.Clone(req.Context())
+	req2.Header.Set("Authorization", "OAuth "+strings.Join(header, ", "))
+
+	return t.transport().RoundTrip(req2)
+}
+
+// Client returns an *http.Client that makes requests signed with the OAuth credentials
+func (t *OAuthTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+func (t *OAuthTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+
+	return http.DefaultTransport
+}
+
+func oauthSignature(req *http.Request, params map[string]string, key *rsa.PrivateKey) (string, error) {
+	pairs := []string{}
+
+	for k, v := range params {
+		pairs = append(pairs, oauthEscape(k)+"="+oauthEscape(v))
+	}
+
+	for k, values := range req.URL.Query() {
+		for _, v := range values {
+			pairs = append(pairs, oauthEscape(k)+"="+oauthEscape(v))
+		}
+	}
+
+	sort.Strings(pairs)
+
+	baseURL := url.URL{
+		Scheme: strings.ToLower(req.URL.Scheme),
+		Host:   strings.ToLower(req.URL.Host),
+		Path:   req.URL.EscapedPath(),
+	}
+
+	if p := baseURL.Port(); (baseURL.Scheme == "http" && p == "80") || (baseURL.Scheme == "https" && p == "443") {
+		baseURL.Host = baseURL.Hostname()
+	}
+
+	base := strings.Join([]string{
+		oauthEscape(strings.ToUpper(req.Method)),
+		oauthEscape(baseURL.Scheme + "://" + baseURL.Host + baseURL.Path),
+		oauthEscape(strings.Join(pairs, "&")),
+	}, "&")
+
+	digest := sha1.Sum([]byte(base))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, digest[:])
+
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+func oauthEscape(s string) string {
+	b := strings.Builder{}
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+// ParseRSAPrivateKey parses a PEM encoded (PKCS #1 or PKCS #8) RSA private key
+func ParseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+
+	if !ok {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return rsaKey, nil
+}
@@ -5,14 +5,71 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
+	"sync"
 	"time"
 
 	jira "github.com/andygrunwald/go-jira"
 )
 
+// ClientOptions represents the options of a Jira Client
+type ClientOptions struct {
+	// Fields optionally maps the logical custom fields to Jira field names or IDs (see DefaultCustomFieldNames)
+	Fields map[string]string
+
+	// Concurrency is the maximum number of search requests run concurrently by the client (DefaultConcurrency when zero)
+	Concurrency int
+
+	// RateLimiter limits the rate of the requests, it can be shared among clients (no limit when nil)
+	RateLimiter *RateLimiter
+
+	// PageSize is the number of issues requested for each search page (DefaultPageSize when zero)
+	PageSize int
+
+	// BatchSize is the maximum number of epics resolved by each linked issues query (DefaultBatchSize when zero)
+	BatchSize int
+
+	// ExtraFields are requested by the searches in addition to the StandardFields and the custom fields (e.g. "comment" or "*all")
+	ExtraFields []string
+
+	// Lenient reports the custom fields that cannot be decoded as warnings (see Client.Warnings) instead of failing
+	Lenient bool
+
+	// MaxRetries is the maximum number of retries of the throttled requests (DefaultMaxRetries when zero, none when negative)
+	MaxRetries int
+
+	// Changelog expands the changelog of the issues found to collect their status transitions
+	Changelog bool
+
+	// Hierarchy resolves the initiatives of the epics and the sub-tasks of their stories (see FindEpics)
+	Hierarchy bool
+
+	// Gates are the gate sets tracked in addition to (or replacing) the DefaultGateSets with the same name
+	Gates map[string]*GateSet
+
+	// Links is the strategy resolving the parents and the stories of the epics (LinksStrategyAuto when empty)
+	Links LinksStrategy
+}
+
 // Client represents a Jira Client definition
 type Client struct {
 	*jira.Client
+	Concurrency   int
+	BatchSize     int
+	PageSize      int
+	ExtraFields   []string
+	Lenient       bool
+	Changelog     bool
+	Hierarchy     bool
+	FieldList     []jira.Field
+	Statuses      Workflow
+	Gates         map[string]*GateSet
+	gateFieldIDs  map[string]string
+	Links         LinksStrategy
+	linksOnce     sync.Once
+	searches      chan struct{}
+	warnings      ErrorList
+	warningsLock  sync.Mutex
 	CustomFieldID struct {
 		ParentLink  string
 		EpicLink    string
@@ -34,59 +91,233 @@ const (
 
 	// NoStoryPoints is a special value used when no story points were set
 	NoStoryPoints int = -1
+
+	// DefaultConcurrency is the default number of search requests run concurrently
+	DefaultConcurrency = 8
+
+	// DefaultPageSize is the default number of issues requested for each search page
+	DefaultPageSize = 50
+
+	// DefaultBatchSize is the default number of epics resolved by each linked issues query
+	DefaultBatchSize = 50
+)
+
+const (
+	// CustomFieldParentLink is the logical name of the Parent Link custom field
+	CustomFieldParentLink = "parent-link"
+
+	// CustomFieldEpicLink is the logical name of the Epic Link custom field
+	CustomFieldEpicLink = "epic-link"
+
+	// CustomFieldStoryPoints is the logical name of the Story Points custom field
+	CustomFieldStoryPoints = "story-points"
+
+	// CustomFieldAckFlags is the logical name of the Ack Flags custom field
+	CustomFieldAckFlags = "ack-flags"
+
+	// CustomFieldQEAssignee is the logical name of the QE Assignee custom field
+	CustomFieldQEAssignee = "qe-assignee"
+
+	// CustomFieldAcceptance is the logical name of the Acceptance Criteria custom field
+	CustomFieldAcceptance = "acceptance"
+
+	// CustomFieldFlagged is the logical name of the Flagged custom field
+	CustomFieldFlagged = "flagged"
+
+	// CustomFieldPlanning is the logical name of the Planning custom field
+	CustomFieldPlanning = "planning"
+
+	// CustomFieldReadiness is the logical name of the Readiness custom field
+	CustomFieldReadiness = "readiness"
+
+	// CustomFieldCommitment is the logical name of the Commitment custom field
+	CustomFieldCommitment = "commitment"
+
+	// CustomFieldDesign is the logical name of the Design Doc custom field
+	CustomFieldDesign = "design"
 )
 
+// StandardFields are the standard fields requested by the searches
+var StandardFields = []string{
+	"summary", "description", "issuetype", "status", "resolution", "resolutiondate", "priority", "assignee",
+	"components", "labels", "fixVersions", "issuelinks", "parent", "created", "updated",
+}
+
+// DefaultCustomFieldNames maps the logical custom fields to the Jira field names used when no mapping is configured
+var DefaultCustomFieldNames = map[string]string{
+	CustomFieldParentLink:  "Parent Link",
+	CustomFieldEpicLink:    "Epic Link",
+	CustomFieldStoryPoints: "Story Points",
+	CustomFieldQEAssignee:  "QE Assignee",
+	CustomFieldAcceptance:  "Acceptance Criteria",
+	CustomFieldFlagged:     "Flagged",
+	CustomFieldPlanning:    "OpenShift Planning",
+	CustomFieldReadiness:   "Ready-Ready",
+	CustomFieldCommitment:  "OpenShift Planning Ack",
+	CustomFieldDesign:      "Design Doc",
+}
+
 // NewClient creates and returns a new Jira Client
-func NewClient(url string, username, password *string) (*Client, error) {
-	var httpClient *http.Client
+func NewClient(url string, credentials *Credentials, options *ClientOptions) (*Client, error) {
+	if options == nil {
+		options = &ClientOptions{}
+	}
+
+	retries := options.MaxRetries
+
+	switch {
+	case retries == 0:
+		retries = DefaultMaxRetries
+	case retries < 0:
+		retries = 0
+	}
+
+	transport := &RetryTransport{
+		Limiter:    options.RateLimiter,
+		MaxRetries: retries,
+		Transport:  credentials.Transport(nil),
+	}
+
+	jiraClient, err := jira.NewClient(&http.Client{Transport: transport}, url)
 
-	if username != nil && *username != "" {
-		transport := jira.BasicAuthTransport{Username: *username, Password: *password}
-		httpClient = transport.Client()
+	if err != nil {
+		return nil, err
 	}
 
-	jiraClient, err := jira.NewClient(httpClient, url)
+	links, err := ParseLinksStrategy(string(options.Links))
 
 	if err != nil {
 		return nil, err
 	}
 
-	fields, ret, err := jiraClient.Field.GetList()
+	fieldList, ret, err := jiraClient.Field.GetList()
 
 	if err := jiraReturnError(ret, err); err != nil {
 		return nil, err
 	}
 
-	client := &Client{Client: jiraClient}
-
-	for _, f := range fields {
-		switch f.Name {
-		case "Parent Link":
-			client.CustomFieldID.ParentLink = f.ID
-		case "Epic Link":
-			client.CustomFieldID.EpicLink = f.ID
-		case "Story Points":
-			client.CustomFieldID.StoryPoints = f.ID
-		case "QE Assignee":
-			client.CustomFieldID.QEAssignee = f.ID
-		case "Acceptance Criteria":
-			client.CustomFieldID.Acceptance = f.ID
-		case "Flagged":
-			client.CustomFieldID.Flagged = f.ID
-		case "OpenShift Planning":
-			client.CustomFieldID.Planning = f.ID
-		case "Ready-Ready":
-			client.CustomFieldID.Readiness = f.ID
-		case "OpenShift Planning Ack":
-			client.CustomFieldID.Commitment = f.ID
-		case "Design Doc":
-			client.CustomFieldID.Design = f.ID
-		}
+	statusList, ret, err := jiraClient.Status.GetAllStatuses()
+
+	if err := jiraReturnError(ret, err); err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		Client:      jiraClient,
+		Concurrency: options.Concurrency,
+		BatchSize:   options.BatchSize,
+		PageSize:    options.PageSize,
+		ExtraFields: options.ExtraFields,
+		Lenient:     options.Lenient,
+		Changelog:   options.Changelog,
+		Hierarchy:   options.Hierarchy,
+		Links:       links,
+		FieldList:   fieldList,
+		Statuses:    newStatusesWorkflow(statusList),
+	}
+
+	if client.Concurrency <= 0 {
+		client.Concurrency = DefaultConcurrency
+	}
+
+	client.searches = make(chan struct{}, client.Concurrency)
+
+	if client.BatchSize <= 0 {
+		client.BatchSize = DefaultBatchSize
+	}
+
+	if client.PageSize <= 0 {
+		client.PageSize = DefaultPageSize
+	}
+
+	if err := client.setCustomFieldIDs(fieldList, options.Fields); err != nil {
+		return nil, err
+	}
+
+	if err := client.setGateFieldIDs(options.Gates); err != nil {
+		return nil, err
 	}
 
 	return client, nil
 }
 
+func (c *Client) customFieldIDs() map[string]*string {
+	return map[string]*string{
+		CustomFieldParentLink:  &c.CustomFieldID.ParentLink,
+		CustomFieldEpicLink:    &c.CustomFieldID.EpicLink,
+		CustomFieldStoryPoints: &c.CustomFieldID.StoryPoints,
+		CustomFieldAckFlags:    &c.CustomFieldID.AckFlags,
+		CustomFieldQEAssignee:  &c.CustomFieldID.QEAssignee,
+		CustomFieldAcceptance:  &c.CustomFieldID.Acceptance,
+		CustomFieldFlagged:     &c.CustomFieldID.Flagged,
+		CustomFieldPlanning:    &c.CustomFieldID.Planning,
+		CustomFieldReadiness:   &c.CustomFieldID.Readiness,
+		CustomFieldCommitment:  &c.CustomFieldID.Commitment,
+		CustomFieldDesign:      &c.CustomFieldID.Design,
+	}
+}
+
+// CustomFields returns the IDs of the logical custom fields found in the instance
+func (c *Client) CustomFields() map[string]string {
+	fields := map[string]string{}
+
+	for k, id := range c.customFieldIDs() {
+		fields[k] = *id
+	}
+
+	return fields
+}
+
+func (c *Client) setCustomFieldIDs(fieldList []jira.Field, fields map[string]string) error {
+	ids := c.customFieldIDs()
+
+	for k := range fields {
+		if _, ok := ids[k]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownField, k)
+		}
+	}
+
+	for k, id := range ids {
+		name, mapped := fields[k]
+
+		if !mapped {
+			name = DefaultCustomFieldNames[k]
+		}
+
+		if name == "" {
+			continue
+		}
+
+		for _, f := range fieldList {
+			if f.ID == name || f.Name == name {
+				*id = f.ID
+				break
+			}
+		}
+
+		if mapped && *id == "" {
+			return fmt.Errorf("%w: %s mapped to '%s'", ErrFieldNotFound, k, name)
+		}
+	}
+
+	return nil
+}
+
+// Warnings returns the errors ignored in lenient mode
+func (c *Client) Warnings() []error {
+	c.warningsLock.Lock()
+	defer c.warningsLock.Unlock()
+
+	return append([]error{}, c.warnings...)
+}
+
+func (c *Client) addWarning(err error) {
+	c.warningsLock.Lock()
+	defer c.warningsLock.Unlock()
+
+	c.warnings = append(c.warnings, err)
+}
+
 // FindProjectComponents finds all the components in the specified project
 func (c *Client) FindProjectComponents(project string) ([]jira.ProjectComponent, error) {
 	p, _, err := c.Project.Get(project)
@@ -98,200 +329,263 @@ func (c *Client) FindProjectComponents(project string) ([]jira.ProjectComponent,
 	return p.Components, nil
 }
 
-// FindIssues finds all the Jira Issues returned by the JQL search
+// ValidateQuery validates the JQL query and returns the number of issues it matches
+func (c *Client) ValidateQuery(jql string) (int, error) {
+	_, ret, err := c.search(jql, &jira.SearchOptions{
+		MaxResults:    1,
+		ValidateQuery: "strict",
+		Fields:        []string{"key"},
+	})
+
+	if err := jiraReturnError(ret, err); err != nil {
+		return 0, err
+	}
+
+	return ret.Total, nil
+}
+
+// search runs the JQL search, the searches of all the callers sharing the client
+// are bounded to Concurrency requests in flight
+func (c *Client) search(jql string, options *jira.SearchOptions) ([]jira.Issue, *jira.Response, error) {
+	c.searches <- struct{}{}
+	defer func() { <-c.searches }()
+
+	return c.Issue.Search(jql, options)
+}
+
+// FindIssues finds all the Jira Issues returned by the JQL search, once the
+// first page reports the total the remaining pages are fetched concurrently
 func (c *Client) FindIssues(jql string) (IssueCollection, error) {
-	issues := NewIssueCollection(0)
-
-	for {
-		issuesPage, ret, err := c.Issue.Search(jql, &jira.SearchOptions{
-			StartAt:       len(issues),
-			MaxResults:    50,
-			ValidateQuery: "strict",
-			Fields:        []string{"*all"},
-		})
+	return c.findIssues(jql, "strict")
+}
 
-		if err := jiraReturnError(ret, err); err != nil {
-			return nil, err
-		}
+// findIssues finds all the Jira Issues returned by the JQL search validated as specified ("strict" or
+// "warn", the latter ignores the keys of the issues not found, e.g. deleted or not visible to the user)
+func (c *Client) findIssues(jql, validateQuery string) (IssueCollection, error) {
+	issuesPage, ret, err := c.search(jql, c.searchOptions(0, validateQuery))
 
-		if len(issuesPage) == 0 {
-			break
-		}
+	if err := jiraReturnError(ret, err); err != nil {
+		return nil, err
+	}
 
-		newIssues := NewIssueCollection(len(issues) + len(issuesPage))
+	issues := NewIssueCollection(ret.Total)
 
-		if copy(newIssues, issues) != len(issues) {
-			return nil, fmt.Errorf("cannot copy issues") // TODO
-		}
+	if len(issues) < len(issuesPage) {
+		issues = NewIssueCollection(len(issuesPage))
+	}
+
+	if err := c.setIssues(issues, 0, issuesPage); err != nil {
+		return nil, err
+	}
 
-		clientURL := c.GetBaseURL()
+	pageSize := len(issuesPage)
 
-		for j, i := range issuesPage {
-			storyPoints := NoStoryPoints
+	if pageSize == 0 {
+		return issues.FilterByFunction(func(i *Issue) bool { return i != nil }), nil
+	}
 
-			if val := i.Fields.Unknowns[c.CustomFieldID.StoryPoints]; val != nil {
-				storyPoints = int(val.(float64))
-			}
+	errs := make(chan error, len(issues)/pageSize+1)
+	wg := sync.WaitGroup{}
 
-			issueReadiness := IssueReadiness{false, false, false, false, false, false}
+	for startAt := pageSize; startAt < len(issues); startAt += pageSize {
+		wg.Add(1)
 
-			if i.Fields.FixVersions != nil && len(i.Fields.FixVersions) > 0 {
-				issueReadiness.Development = true
-				issueReadiness.Product = true
-			}
+		go func(startAt int) {
+			defer wg.Done()
 
-			if val := i.Fields.Unknowns[c.CustomFieldID.Readiness]; val != nil {
-				for _, r := range val.([]interface{}) {
-					switch r.(map[string]interface{})["value"].(string) {
-					case "dev-ready":
-						issueReadiness.Development = true
-					case "pm-ready":
-						issueReadiness.Product = true
-					case "doc-ready":
-						issueReadiness.Documentation = true
-					case "px-ready":
-						issueReadiness.Support = true
-					case "qa-ready":
-						issueReadiness.Quality = true
-					case "ux-ready":
-						issueReadiness.Experience = true
-					}
-				}
-			}
+			issuesPage, ret, err := c.search(jql, c.searchOptions(startAt, validateQuery))
 
-			issuePlanning := IssuePlanning{false, false, false}
-
-			if val := i.Fields.Unknowns[c.CustomFieldID.Planning]; val != nil {
-				for _, p := range val.([]interface{}) {
-					switch p.(map[string]interface{})["value"].(string) {
-					case "no-feature":
-						issuePlanning.NoFeature = true
-					case "no-doc":
-						issuePlanning.NoDocumentation = true
-					case "no-qe":
-						issuePlanning.NoQuality = true
-					}
-				}
+			if err := jiraReturnError(ret, err); err != nil {
+				errs <- err
+				return
 			}
 
-			issueCommitment := IssueCommitment{false, false, false}
-
-			if val := i.Fields.Unknowns[c.CustomFieldID.Commitment]; val != nil {
-				for _, p := range val.([]interface{}) {
-					switch p.(map[string]interface{})["value"].(string) {
-					case "qe-ack":
-						issueCommitment.Quality = true
-					case "doc-ack":
-						issueCommitment.Documentation = true
-					case "px-ack":
-						issueCommitment.Support = true
-					}
-				}
+			if err := c.setIssues(issues, startAt, issuesPage); err != nil {
+				errs <- err
 			}
+		}(startAt)
+	}
 
-			designLink := ""
+	wg.Wait()
+	close(errs)
 
-			if val := i.Fields.Unknowns[c.CustomFieldID.Design]; val != nil {
-				designLink = val.(string)
-			}
+	if err := <-errs; err != nil {
+		return nil, err
+	}
 
-			parentLink := ""
+	return issues.FilterByFunction(func(i *Issue) bool { return i != nil }), nil
+}
 
-			if val := i.Fields.Unknowns[c.CustomFieldID.ParentLink]; val != nil {
-				parentLink = val.(string)
-			}
+func (c *Client) searchOptions(startAt int, validateQuery string) *jira.SearchOptions {
+	options := &jira.SearchOptions{
+		StartAt:       startAt,
+		MaxResults:    c.PageSize,
+		ValidateQuery: validateQuery,
+		Fields:        c.SearchFields(),
+	}
 
-			if val := i.Fields.Unknowns[c.CustomFieldID.EpicLink]; i.Fields.Epic == nil && val != nil {
-				i.Fields.Epic = &jira.Epic{Key: val.(string)}
-			}
+	if c.Changelog {
+		options.Expand = "changelog"
+	}
 
-			qeAssignee := ""
+	return options
+}
 
-			if val := i.Fields.Unknowns[c.CustomFieldID.QEAssignee]; val != nil {
-				qeAssignee = (val.(map[string]interface{})["key"]).(string)
-			}
+// SearchFields returns the fields requested by the searches: the standard
+// fields, the custom fields found in the instance and the extra fields
+func (c *Client) SearchFields() []string {
+	fields := append([]string{}, StandardFields...)
+	found := map[string]bool{}
 
-			acceptanceCriteria := ""
+	for _, id := range c.customFieldIDs() {
+		if *id != "" && !found[*id] {
+			found[*id] = true
+			fields = append(fields, *id)
+		}
+	}
 
-			if val := i.Fields.Unknowns[c.CustomFieldID.Acceptance]; val != nil {
-				acceptanceCriteria = val.(string)
-			}
+	for _, id := range c.gateFieldIDs {
+		if id != "" && !found[id] {
+			found[id] = true
+			fields = append(fields, id)
+		}
+	}
 
-			deliveryOwner := ""
-			deliveryOwnerMatches := regexp.MustCompile(DeliveryOwnerRegExp).FindStringSubmatch(i.Fields.Description)
+	sort.Strings(fields[len(StandardFields):])
 
-			if len(deliveryOwnerMatches) == 3 {
-				deliveryOwner = deliveryOwnerMatches[2]
-			} else if i.Fields.Assignee != nil {
-				deliveryOwner = i.Fields.Assignee.Name
-			}
+	return append(fields, c.ExtraFields...)
+}
 
-			impediment := false
+func (c *Client) setIssues(issues IssueCollection, startAt int, issuesPage []jira.Issue) error {
+	for j, i := range issuesPage {
+		if startAt+j >= len(issues) {
+			break
+		}
 
-			if val := i.Fields.Unknowns[c.CustomFieldID.Flagged]; val != nil {
-				for _, f := range val.([]interface{}) {
-					switch f.(map[string]interface{})["value"].(string) {
-					case "Impediment":
-						impediment = true
-					}
-				}
-			}
+		issue, err := c.newIssue(i)
 
-			issueURL := url.URL{
-				Scheme: clientURL.Scheme,
-				Host:   clientURL.Host,
-				Path:   clientURL.Path + "browse/" + i.Key,
-			}
+		if err != nil {
+			return err
+		}
 
-			issueComments := []*Comment{}
+		issues[startAt+j] = issue
+	}
 
-			for _, c := range i.Fields.Comments.Comments {
-				commentCreateTime, err := time.Parse(JiraTimeLayout, c.Created)
+	return nil
+}
 
-				if err != nil {
-					return nil, err
-				}
+func (c *Client) newIssue(i jira.Issue) (*Issue, error) {
+	clientURL := c.GetBaseURL()
 
-				commentUpdateTime, err := time.Parse(JiraTimeLayout, c.Updated)
+	fields := c.newFieldDecoder(&i)
 
-				if err != nil {
-					return nil, err
-				}
+	storyPoints := NoStoryPoints
 
-				issueComments = append(issueComments, &Comment{
-					Comment: c,
-					Created: commentCreateTime,
-					Updated: commentUpdateTime,
-				})
-			}
+	if val, ok := fields.Number(CustomFieldStoryPoints); ok {
+		storyPoints = int(val)
+	}
 
-			newIssues[len(issues)+j] = &Issue{
-				i,
-				issueURL.String(),
-				parentLink,
-				nil,
-				NewIssueCollection(0),
-				storyPoints,
-				issueReadiness,
-				issuePlanning,
-				issueCommitment,
-				designLink,
-				qeAssignee,
-				acceptanceCriteria,
-				deliveryOwner,
-				impediment,
-				issueComments,
-			}
+	issueGates := c.issueGates(&i, fields)
+
+	designLink, _ := fields.String(CustomFieldDesign)
+	parentLink, _ := fields.String(CustomFieldParentLink)
+
+	if val, ok := fields.String(CustomFieldEpicLink); i.Fields.Epic == nil && ok {
+		i.Fields.Epic = &jira.Epic{Key: val}
+	}
+
+	qeAssignee, _ := fields.User(CustomFieldQEAssignee)
+	acceptanceCriteria, _ := fields.String(CustomFieldAcceptance)
+
+	deliveryOwner := ""
+	deliveryOwnerMatches := regexp.MustCompile(DeliveryOwnerRegExp).FindStringSubmatch(i.Fields.Description)
+
+	if len(deliveryOwnerMatches) == 3 {
+		deliveryOwner = deliveryOwnerMatches[2]
+	} else if i.Fields.Assignee != nil {
+		deliveryOwner = i.Fields.Assignee.Name
+	}
+
+	impediment := false
+
+	for _, f := range fields.Options(CustomFieldFlagged) {
+		switch f {
+		case "Impediment":
+			impediment = true
 		}
+	}
+
+	if err := fields.Err(); err != nil {
+		return nil, err
+	}
 
-		issues = newIssues
+	issueURL := url.URL{
+		Scheme: clientURL.Scheme,
+		Host:   clientURL.Host,
+		Path:   clientURL.Path + "browse/" + i.Key,
 	}
 
-	return issues, nil
+	issueComments := []*Comment{}
+
+	if i.Fields.Comments == nil {
+		i.Fields.Comments = &jira.Comments{}
+	}
+
+	for _, c := range i.Fields.Comments.Comments {
+		commentCreateTime, err := time.Parse(JiraTimeLayout, c.Created)
+
+		if err != nil {
+			return nil, err
+		}
+
+		commentUpdateTime, err := time.Parse(JiraTimeLayout, c.Updated)
+
+		if err != nil {
+			return nil, err
+		}
+
+		issueComments = append(issueComments, &Comment{
+			Comment: c,
+			Created: commentCreateTime,
+			Updated: commentUpdateTime,
+		})
+	}
+
+	transitions, err := newStatusTransitions(i.Changelog)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Issue{
+		i,
+		issueURL.String(),
+		parentLink,
+		nil,
+		nil,
+		NewIssueCollection(0),
+		nil,
+		storyPoints,
+		issueGates,
+		designLink,
+		qeAssignee,
+		acceptanceCriteria,
+		deliveryOwner,
+		impediment,
+		issueComments,
+		transitions,
+		nil,
+	}, nil
 }
 
-// FindEpics finds all the Jira Epics returned by the JQL search
-func (c *Client) FindEpics(jql string) (IssueCollection, error) {
+// FindEpics finds all the Jira Epics returned by the JQL search, with their stories and their parents (see
+// DefaultParentLinks when nil) resolved with the links strategy of the client (see ResolveLinksStrategy), and
+// optionally (see ClientOptions.Hierarchy) their initiative and the sub-tasks of the stories
+func (c *Client) FindEpics(jql string, parentLinks *ParentLinks) (IssueCollection, error) {
+	if parentLinks == nil {
+		parentLinks = DefaultParentLinks
+	}
+
 	issues, err := c.FindIssues(jql)
 
 	if err != nil {
@@ -302,47 +596,51 @@ func (c *Client) FindEpics(jql string) (IssueCollection, error) {
 		return i.IsType(IssueTypeEpic)
 	})
 
-	ch := make(chan error)
-	defer close(ch)
+	strategy := c.ResolveLinksStrategy()
 
-	for _, i := range epics {
-		go func(i *Issue, ch chan<- error) { ch <- addLinkedIssues(c, i) }(i, ch)
-	}
+	work := make(chan IssueCollection)
+	errs := make(chan error, 2*len(epics))
+	wg := sync.WaitGroup{}
 
-	linksErr := error(nil)
+	for n := 0; n < c.Concurrency; n++ {
+		wg.Add(1)
 
-	for range epics {
-		if err := <-ch; err != nil {
-			linksErr = err
-		}
-	}
+		go func() {
+			defer wg.Done()
 
-	return issues, linksErr
-}
+			for b := range work {
+				if err := addLinkedIssues(c, b, parentLinks, strategy); err != nil {
+					errs <- err
+				}
 
-func addLinkedIssues(c *Client, i *Issue) error {
-	jql := fmt.Sprintf("issueFunction in linkedIssuesOfRecursive(\"issue = %s\", \"is child of\") AND type = \"Market Problem\"", i.Key)
-	marketProblem, err := c.FindIssues(jql)
+				if !c.Hierarchy {
+					continue
+				}
 
-	switch {
-	case err != nil:
-		return err
-	case len(marketProblem) > 1:
-		return ErrMultipleIssues
-	case len(marketProblem) < 1:
-		return nil
+				if err := addHierarchy(c, b); err != nil {
+					errs <- err
+				}
+			}
+		}()
 	}
 
-	i.MarketProblem = marketProblem[0]
+	for _, b := range epics.Chunks(c.BatchSize) {
+		work <- b
+	}
 
-	jql = fmt.Sprintf("issueFunction in issuesInEpics(\"Key = %s\")", i.Key)
-	linkedIssues, err := c.FindIssues(jql)
+	close(work)
+	wg.Wait()
+	close(errs)
 
-	if err != nil {
-		return err
-	}
+	linksErr := ErrorList{}
 
-	i.LinkedIssues = linkedIssues
+	for err := range errs {
+		if l, ok := err.(ErrorList); ok {
+			linksErr = append(linksErr, l...)
+		} else {
+			linksErr = append(linksErr, err)
+		}
+	}
 
-	return nil
+	return issues, linksErr.ErrorOrNil()
 }
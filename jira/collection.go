@@ -21,6 +21,33 @@ func (c IssueCollection) FilterByFunction(fn func(*Issue) bool) IssueCollection
 	return r
 }
 
+// Keys returns the keys of the issues in the collection
+func (c IssueCollection) Keys() []string {
+	keys := make([]string, len(c))
+
+	for n, i := range c {
+		keys[n] = i.Key
+	}
+
+	return keys
+}
+
+// Chunks splits the collection in chunks of up to size issues
+func (c IssueCollection) Chunks(size int) []IssueCollection {
+	chunks := []IssueCollection{}
+
+	for len(c) > size {
+		chunks = append(chunks, c[:size:size])
+		c = c[size:]
+	}
+
+	if len(c) > 0 {
+		chunks = append(chunks, c)
+	}
+
+	return chunks
+}
+
 // StoryPoints returns the total number of story points for the issues in the collection
 func (c IssueCollection) StoryPoints() int {
 	points := 0
@@ -50,7 +77,7 @@ func (c IssueCollection) Progress() Progress {
 	p := Progress{Total: 0, Status: 0, Unknown: 0}
 
 	for _, i := range c {
-		if i.InStatus(IssueStatusObsolete) {
+		if i.IsExcluded() {
 			continue
 		}
 
@@ -69,7 +96,7 @@ func (c IssueCollection) StoryPointsProgress() Progress {
 	p := Progress{Total: 0, Status: 0, Unknown: 0}
 
 	for _, i := range c {
-		if !i.IsType(IssueTypeStory) || i.InStatus(IssueStatusObsolete) {
+		if !i.IsType(IssueTypeStory) || i.IsExcluded() {
 			continue
 		}
 
@@ -0,0 +1,176 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// fieldDecoder decodes the custom fields of an issue validating their shape,
+// in lenient mode the invalid fields are reported as warnings and left unset
+type fieldDecoder struct {
+	client *Client
+	issue  *jira.Issue
+	ids    map[string]*string
+	err    error
+}
+
+func (c *Client) newFieldDecoder(i *jira.Issue) *fieldDecoder {
+	return &fieldDecoder{client: c, issue: i, ids: c.customFieldIDs()}
+}
+
+func (d *fieldDecoder) value(field string) (string, interface{}) {
+	id := *d.ids[field]
+
+	if id == "" {
+		return id, nil
+	}
+
+	return id, d.issue.Fields.Unknowns[id]
+}
+
+func (d *fieldDecoder) fail(field, id, expected string, val interface{}) {
+	err := &FieldError{d.issue.Key, field, id, fmt.Errorf("expected %s, found %s", expected, jsonTypeName(val))}
+
+	if d.client.Lenient {
+		d.client.addWarning(err)
+		return
+	}
+
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// Number decodes a numeric field
+func (d *fieldDecoder) Number(field string) (float64, bool) {
+	id, val := d.value(field)
+
+	switch v := val.(type) {
+	case nil:
+		return 0, false
+	case float64:
+		return v, true
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f, true
+		}
+	}
+
+	d.fail(field, id, "number", val)
+
+	return 0, false
+}
+
+// String decodes a text field
+func (d *fieldDecoder) String(field string) (string, bool) {
+	id, val := d.value(field)
+
+	switch v := val.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	}
+
+	d.fail(field, id, "string", val)
+
+	return "", false
+}
+
+// Options decodes the values of a select or multi-select field
+func (d *fieldDecoder) Options(field string) []string {
+	id, _ := d.value(field)
+	return d.OptionsByID(field, id)
+}
+
+// OptionsByID decodes the values of a select or multi-select field that is not a logical custom field
+func (d *fieldDecoder) OptionsByID(field, id string) []string {
+	var val interface{}
+
+	if id != "" {
+		val = d.issue.Fields.Unknowns[id]
+	}
+
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		if s, ok := v["value"].(string); ok {
+			return []string{s}
+		}
+	case []interface{}:
+		options := make([]string, 0, len(v))
+
+		for _, o := range v {
+			m, ok := o.(map[string]interface{})
+
+			if !ok {
+				d.fail(field, id, "list of options", val)
+				return nil
+			}
+
+			s, ok := m["value"].(string)
+
+			if !ok {
+				d.fail(field, id, "list of options", val)
+				return nil
+			}
+
+			options = append(options, s)
+		}
+
+		return options
+	}
+
+	d.fail(field, id, "list of options", val)
+
+	return nil
+}
+
+// User decodes the key (or the account id) of a user field
+func (d *fieldDecoder) User(field string) (string, bool) {
+	id, val := d.value(field)
+
+	switch v := val.(type) {
+	case nil:
+		return "", false
+	case map[string]interface{}:
+		if s, ok := v["key"].(string); ok {
+			return s, true
+		}
+
+		if s, ok := v["accountId"].(string); ok {
+			return s, true
+		}
+	}
+
+	d.fail(field, id, "user", val)
+
+	return "", false
+}
+
+// Err returns the first error found decoding the fields
+func (d *fieldDecoder) Err() error {
+	return d.err
+}
+
+func jsonTypeName(val interface{}) string {
+	switch val.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64, json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", val)
+}
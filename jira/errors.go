@@ -0,0 +1,68 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorList represents a list of errors
+type ErrorList []error
+
+func (l ErrorList) Error() string {
+	messages := make([]string, len(l))
+
+	for n, e := range l {
+		messages[n] = e.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Is returns true if any of the errors in the list matches target
+func (l ErrorList) Is(target error) bool {
+	for _, e := range l {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first error in the list that matches target
+func (l ErrorList) As(target interface{}) bool {
+	for _, e := range l {
+		if errors.As(e, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ErrorOrNil returns nil if the list is empty or the list itself otherwise
+func (l ErrorList) ErrorOrNil() error {
+	if len(l) == 0 {
+		return nil
+	}
+
+	return l
+}
+
+// FieldError represents an error decoding a field of an issue
+type FieldError struct {
+	Key   string
+	Field string
+	ID    string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: field %s (%s): %s", e.Key, e.Field, e.ID, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,247 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// GateSet represents a multi-select field whose options are tracked as gates
+type GateSet struct {
+	// Field is the logical custom field (see DefaultCustomFieldNames) or the Jira field name or ID
+	Field string
+
+	// Options are the option values tracked, the other values are ignored
+	Options []string
+
+	// FixVersion are the options considered set when the issue has a fix version
+	FixVersion []string `yaml:"fix-version"`
+}
+
+const (
+	// GateSetReadiness is the name of the default readiness gate set
+	GateSetReadiness = "readiness"
+
+	// GateSetPlanning is the name of the default planning gate set
+	GateSetPlanning = "planning"
+
+	// GateSetCommitment is the name of the default commitment gate set
+	GateSetCommitment = "commitment"
+)
+
+// DefaultGateSets are the gate sets tracked when no gate set is configured with the same name
+var DefaultGateSets = map[string]*GateSet{
+	GateSetReadiness: {
+		Field:      CustomFieldReadiness,
+		Options:    []string{"dev-ready", "pm-ready", "qa-ready", "ux-ready", "doc-ready", "px-ready"},
+		FixVersion: []string{"dev-ready", "pm-ready"},
+	},
+	GateSetPlanning: {
+		Field:   CustomFieldPlanning,
+		Options: []string{"no-feature", "no-doc", "no-qe"},
+	},
+	GateSetCommitment: {
+		Field:   CustomFieldCommitment,
+		Options: []string{"qe-ack", "doc-ack", "px-ack"},
+	},
+}
+
+// DefaultReadyRule is the rule used by Issue.Ready
+var DefaultReadyRule = MustParseGateRule([]string{
+	"dev: readiness.dev-ready",
+	"pm: readiness.pm-ready",
+	"qa: readiness.qa-ready",
+	"ux: readiness.ux-ready",
+	"doc: readiness.doc-ready",
+	"px: readiness.px-ready",
+})
+
+// DefaultCommittedRule is the rule used by Issue.IsCommitted
+var DefaultCommittedRule = MustParseGateRule([]string{
+	"qe: commitment.qe-ack | planning.no-qe",
+	"doc: commitment.doc-ack | planning.no-doc",
+	"px: commitment.px-ack",
+})
+
+// IssueGates maps the gate sets to the options set on an issue
+type IssueGates map[string][]string
+
+// Has returns true if the option of the gate set is set
+func (g IssueGates) Has(set, option string) bool {
+	for _, o := range g[set] {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Gate represents an option of a gate set
+type Gate struct {
+	Set    string
+	Option string
+}
+
+// String returns the gate in the "<set>.<option>" form
+func (g Gate) String() string {
+	return g.Set + "." + g.Option
+}
+
+// GateRequirement is satisfied when any of its gates is set, the gates after
+// the first one are exemptions (e.g. "qe: commitment.qe-ack | planning.no-qe")
+type GateRequirement struct {
+	Label string
+	Gates []Gate
+}
+
+// Check returns whether the requirement is satisfied and whether it is satisfied by an exemption
+func (r *GateRequirement) Check(i *Issue) (bool, bool) {
+	for n, g := range r.Gates {
+		if i.Gates.Has(g.Set, g.Option) {
+			return true, n > 0
+		}
+	}
+
+	return false, false
+}
+
+// GateRule represents the requirements that must be all satisfied
+type GateRule []*GateRequirement
+
+// ParseGateRule parses the requirements of a rule, each one in the "[<label>:] <set>.<option> [| <set>.<option>...]"
+// form, the label defaults to the option of the first gate
+func ParseGateRule(requirements []string) (GateRule, error) {
+	rule := GateRule{}
+
+	for _, r := range requirements {
+		requirement := &GateRequirement{}
+
+		if n := strings.Index(r, ":"); n >= 0 {
+			requirement.Label, r = strings.TrimSpace(r[:n]), r[n+1:]
+		}
+
+		for _, g := range strings.Split(r, "|") {
+			parts := strings.SplitN(strings.TrimSpace(g), ".", 2)
+
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return nil, fmt.Errorf("%w: '%s'", ErrInvalidGate, strings.TrimSpace(g))
+			}
+
+			requirement.Gates = append(requirement.Gates, Gate{parts[0], parts[1]})
+		}
+
+		if requirement.Label == "" {
+			requirement.Label = requirement.Gates[0].Option
+		}
+
+		rule = append(rule, requirement)
+	}
+
+	return rule, nil
+}
+
+// MustParseGateRule is like ParseGateRule but panics if the rule cannot be parsed
+func MustParseGateRule(requirements []string) GateRule {
+	rule, err := ParseGateRule(requirements)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return rule
+}
+
+// Sets returns the names of the gate sets referenced by the rule
+func (r GateRule) Sets() []string {
+	sets := []string{}
+	found := map[string]bool{}
+
+	for _, q := range r {
+		for _, g := range q.Gates {
+			if !found[g.Set] {
+				found[g.Set] = true
+				sets = append(sets, g.Set)
+			}
+		}
+	}
+
+	return sets
+}
+
+// Satisfies returns true if the issue satisfies all the requirements of the rule
+func (i *Issue) Satisfies(rule GateRule) bool {
+	for _, r := range rule {
+		if ok, _ := r.Check(i); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Client) issueGates(i *jira.Issue, fields *fieldDecoder) IssueGates {
+	gates := IssueGates{}
+
+	for name, set := range c.Gates {
+		selected := map[string]bool{}
+
+		for _, o := range fields.OptionsByID(set.Field, c.gateFieldIDs[name]) {
+			selected[o] = true
+		}
+
+		if len(i.Fields.FixVersions) > 0 {
+			for _, o := range set.FixVersion {
+				selected[o] = true
+			}
+		}
+
+		options := []string{}
+
+		for _, o := range set.Options {
+			if selected[o] {
+				options = append(options, o)
+			}
+		}
+
+		gates[name] = options
+	}
+
+	return gates
+}
+
+func (c *Client) setGateFieldIDs(gates map[string]*GateSet) error {
+	c.Gates = map[string]*GateSet{}
+	c.gateFieldIDs = map[string]string{}
+
+	for name, set := range DefaultGateSets {
+		c.Gates[name] = set
+	}
+
+	for name, set := range gates {
+		c.Gates[name] = set
+	}
+
+	ids := c.customFieldIDs()
+
+	for name, set := range c.Gates {
+		if id, ok := ids[set.Field]; ok {
+			c.gateFieldIDs[name] = *id
+			continue
+		}
+
+		for _, f := range c.FieldList {
+			if f.ID == set.Field || f.Name == set.Field {
+				c.gateFieldIDs[name] = f.ID
+				break
+			}
+		}
+
+		if c.gateFieldIDs[name] == "" {
+			return fmt.Errorf("%w: gate set %s field '%s'", ErrFieldNotFound, name, set.Field)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxHierarchyDepth is the maximum number of Parent Link levels followed from an epic to its initiative
+const MaxHierarchyDepth = 3
+
+// HierarchyNode represents an issue and its children in the Initiative → Epic → Story → Sub-task hierarchy
+type HierarchyNode struct {
+	Issue    *Issue
+	Children []*HierarchyNode
+}
+
+// Hierarchy represents the issues organized by initiative
+type Hierarchy struct {
+	Initiatives []*HierarchyNode
+	Orphans     []*HierarchyNode
+}
+
+// Rollup represents the aggregated progress of an issue and of its descendants
+type Rollup struct {
+	Issues      Progress
+	StoryPoints Progress
+}
+
+// NewHierarchy creates and returns the hierarchy of the epics (and their stories and sub-tasks), the epics
+// without initiative are orphans
+func NewHierarchy(epics IssueCollection) *Hierarchy {
+	h := &Hierarchy{}
+	initiatives := map[string]*HierarchyNode{}
+
+	for _, e := range epics {
+		node := newHierarchyNode(e)
+
+		if e.Initiative == nil {
+			h.Orphans = append(h.Orphans, node)
+			continue
+		}
+
+		initiative, ok := initiatives[e.Initiative.Key]
+
+		if !ok {
+			initiative = &HierarchyNode{Issue: e.Initiative}
+			initiatives[e.Initiative.Key] = initiative
+			h.Initiatives = append(h.Initiatives, initiative)
+		}
+
+		initiative.Children = append(initiative.Children, node)
+	}
+
+	return h
+}
+
+func newHierarchyNode(i *Issue) *HierarchyNode {
+	node := &HierarchyNode{Issue: i}
+
+	for _, s := range i.LinkedIssues {
+		node.Children = append(node.Children, newHierarchyNode(s))
+	}
+
+	for _, s := range i.Subtasks {
+		node.Children = append(node.Children, newHierarchyNode(s))
+	}
+
+	return node
+}
+
+// Issues returns the issues of the children nodes
+func (n *HierarchyNode) Issues() IssueCollection {
+	issues := NewIssueCollection(len(n.Children))
+
+	for k, c := range n.Children {
+		issues[k] = c.Issue
+	}
+
+	return issues
+}
+
+// Descendants returns all the issues below the node
+func (n *HierarchyNode) Descendants() IssueCollection {
+	issues := NewIssueCollection(0)
+
+	for _, c := range n.Children {
+		issues = append(issues, c.Issue)
+		issues = append(issues, c.Descendants()...)
+	}
+
+	return issues
+}
+
+// Rollup returns the progress of all the descendants and of the story points of the descendant stories
+func (n *HierarchyNode) Rollup() Rollup {
+	descendants := n.Descendants()
+
+	return Rollup{
+		Issues:      descendants.Progress(),
+		StoryPoints: descendants.StoryPointsProgress(),
+	}
+}
+
+// addHierarchy resolves the initiatives of the epics, following their Parent Link, and the sub-tasks of their stories
+func addHierarchy(c *Client, epics IssueCollection) error {
+	index := map[string]*Issue{}
+	pending := epics
+
+	for depth := 0; depth < MaxHierarchyDepth && len(pending) > 0; depth++ {
+		keys := []string{}
+
+		for _, i := range pending {
+			if _, ok := index[i.ParentLink]; i.ParentLink != "" && !ok {
+				index[i.ParentLink] = nil
+				keys = append(keys, i.ParentLink)
+			}
+		}
+
+		if len(keys) == 0 {
+			break
+		}
+
+		// the keys not found (deleted or not visible) are ignored, instead of failing the whole batch
+		parents, err := c.findIssues(fmt.Sprintf("key in (%s)", strings.Join(keys, ", ")), "warn")
+
+		if err != nil {
+			return err
+		}
+
+		for _, p := range parents {
+			index[p.Key] = p
+		}
+
+		pending = parents.FilterByFunction(func(i *Issue) bool {
+			return !i.IsType(IssueTypeInitiative)
+		})
+	}
+
+	for _, e := range epics {
+		for p, depth := index[e.ParentLink], 0; p != nil && depth < MaxHierarchyDepth; p, depth = index[p.ParentLink], depth+1 {
+			if p.IsType(IssueTypeInitiative) {
+				e.Initiative = p
+				break
+			}
+		}
+	}
+
+	stories := map[string]*Issue{}
+	storiesList := IssueCollection{}
+
+	for _, e := range epics {
+		for _, s := range e.LinkedIssues {
+			stories[s.Key] = s
+			storiesList = append(storiesList, s)
+		}
+	}
+
+	for _, b := range storiesList.Chunks(c.BatchSize) {
+		subtasks, err := c.FindIssues(fmt.Sprintf("parent in (%s)", strings.Join(b.Keys(), ", ")))
+
+		if err != nil {
+			return err
+		}
+
+		for _, t := range subtasks {
+			if t.Fields.Parent == nil {
+				continue
+			}
+
+			if s, ok := stories[t.Fields.Parent.Key]; ok {
+				s.Subtasks = append(s.Subtasks, t)
+			}
+		}
+	}
+
+	return nil
+}
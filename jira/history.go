@@ -0,0 +1,218 @@
+package jira
+
+import (
+	"sort"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// StatusTransition represents a change of the status of an issue
+type StatusTransition struct {
+	From string
+	To   string
+	Time time.Time
+}
+
+// StatusInterval represents a period of time spent by an issue in a status
+type StatusInterval struct {
+	Status string
+	Start  time.Time
+	End    time.Time
+}
+
+// Duration returns the duration of the interval
+func (s *StatusInterval) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+func newStatusTransitions(changelog *jira.Changelog) ([]*StatusTransition, error) {
+	if changelog == nil {
+		return nil, nil
+	}
+
+	transitions := []*StatusTransition{}
+
+	for _, h := range changelog.Histories {
+		for _, item := range h.Items {
+			if item.Field != "status" {
+				continue
+			}
+
+			created, err := time.Parse(JiraTimeLayout, h.Created)
+
+			if err != nil {
+				return nil, err
+			}
+
+			transitions = append(transitions, &StatusTransition{item.FromString, item.ToString, created})
+		}
+	}
+
+	sort.SliceStable(transitions, func(a, b int) bool {
+		return transitions[a].Time.Before(transitions[b].Time)
+	})
+
+	return transitions, nil
+}
+
+// HasHistory returns true if the status transitions of the issue were collected from the changelog
+func (i *Issue) HasHistory() bool {
+	return i.Transitions != nil
+}
+
+// StatusIntervals returns the periods of time spent in each status from the creation of the issue until now
+func (i *Issue) StatusIntervals(now time.Time) []*StatusInterval {
+	intervals := []*StatusInterval{}
+	start := time.Time(i.Fields.Created)
+	status := ""
+
+	if i.Fields.Status != nil {
+		status = i.Fields.Status.Name
+	}
+
+	if len(i.Transitions) > 0 {
+		status = i.Transitions[0].From
+	}
+
+	for _, t := range i.Transitions {
+		intervals = append(intervals, &StatusInterval{status, start, t.Time})
+		start, status = t.Time, t.To
+	}
+
+	return append(intervals, &StatusInterval{status, start, now})
+}
+
+// StatusSince returns the time when the issue entered its current status
+func (i *Issue) StatusSince() time.Time {
+	if len(i.Transitions) == 0 {
+		return time.Time(i.Fields.Created)
+	}
+
+	return i.Transitions[len(i.Transitions)-1].Time
+}
+
+// statusCategory returns the category of a status of the issue history
+func (i *Issue) statusCategory(status string) StatusCategory {
+	if i.Fields.Status != nil && i.Fields.Status.Name == status {
+		return i.StatusCategory()
+	}
+
+	if c, ok := i.workflow().Category(status); ok {
+		return c
+	}
+
+	return StatusCategoryTodo
+}
+
+// TimeInCategories returns the time spent by the issue in each status category until now
+func (i *Issue) TimeInCategories(now time.Time) map[StatusCategory]time.Duration {
+	times := map[StatusCategory]time.Duration{}
+
+	for _, s := range i.StatusIntervals(now) {
+		times[i.statusCategory(s.Status)] += s.Duration()
+	}
+
+	return times
+}
+
+// doneSince returns the time when the issue entered the done category for the last time
+func (i *Issue) doneSince() (time.Time, bool) {
+	if i.StatusCategory() != StatusCategoryDone {
+		return time.Time{}, false
+	}
+
+	intervals := i.StatusIntervals(time.Now())
+	since := intervals[len(intervals)-1].Start
+
+	for n := len(intervals) - 2; n >= 0 && i.statusCategory(intervals[n].Status) == StatusCategoryDone; n-- {
+		since = intervals[n].Start
+	}
+
+	return since, true
+}
+
+// LeadTime returns the time from the creation of the issue until it was done, ok is false when the issue is not done
+func (i *Issue) LeadTime() (time.Duration, bool) {
+	done, ok := i.doneSince()
+
+	if !ok {
+		return 0, false
+	}
+
+	return done.Sub(time.Time(i.Fields.Created)), true
+}
+
+// CycleTime returns the time from when the work on the issue started until it was done, ok is false
+// when the issue is not done or it was never active
+func (i *Issue) CycleTime() (time.Duration, bool) {
+	done, ok := i.doneSince()
+
+	if !ok {
+		return 0, false
+	}
+
+	for _, s := range i.StatusIntervals(done) {
+		if i.statusCategory(s.Status) == StatusCategoryActive {
+			return done.Sub(s.Start), true
+		}
+	}
+
+	return 0, false
+}
+
+// AverageLeadTime returns the average lead time of the done issues in the collection, ok is false when none is done
+func (c IssueCollection) AverageLeadTime() (time.Duration, bool) {
+	return c.averageDuration((*Issue).LeadTime)
+}
+
+// AverageCycleTime returns the average cycle time of the done issues in the collection, ok is false when none is done
+func (c IssueCollection) AverageCycleTime() (time.Duration, bool) {
+	return c.averageDuration((*Issue).CycleTime)
+}
+
+func (c IssueCollection) averageDuration(fn func(*Issue) (time.Duration, bool)) (time.Duration, bool) {
+	total, count := time.Duration(0), 0
+
+	for _, i := range c {
+		if d, ok := fn(i); ok {
+			total += d
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0, false
+	}
+
+	return total / time.Duration(count), true
+}
+
+// TimeInCategories returns the time spent by all the issues of the collection in each status category until now
+func (c IssueCollection) TimeInCategories(now time.Time) map[StatusCategory]time.Duration {
+	times := map[StatusCategory]time.Duration{}
+
+	for _, i := range c {
+		for k, d := range i.TimeInCategories(now) {
+			times[k] += d
+		}
+	}
+
+	return times
+}
+
+// ResolvedTime returns the time when the issue was resolved, from the status history when available
+// or from the resolution date, ok is false when the issue is not resolved
+func (i *Issue) ResolvedTime() (time.Time, bool) {
+	if i.HasHistory() {
+		return i.doneSince()
+	}
+
+	resolved := time.Time(i.Fields.Resolutiondate)
+
+	if !i.IsResolved() || resolved.IsZero() {
+		return time.Time{}, false
+	}
+
+	return resolved, true
+}
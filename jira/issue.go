@@ -2,53 +2,32 @@ package jira
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	jira "github.com/andygrunwald/go-jira"
 )
 
-// IssueReadiness represents a Jira Issue Approvals
-type IssueReadiness struct {
-	Development   bool
-	Product       bool
-	Quality       bool
-	Experience    bool
-	Documentation bool
-	Support       bool
-}
-
-// IssuePlanning represents a Jira Issue Planning
-type IssuePlanning struct {
-	NoFeature       bool
-	NoDocumentation bool
-	NoQuality       bool
-}
-
-// IssueCommitment represents a Jira Issue Commitment
-type IssueCommitment struct {
-	Quality       bool
-	Documentation bool
-	Support       bool
-}
-
 // Issue represents a Jira Issue
 type Issue struct {
 	jira.Issue
-	Link          string
-	ParentLink    string
-	MarketProblem *Issue
-	LinkedIssues  IssueCollection
-	StoryPoints   int
-	Readiness     IssueReadiness
-	Planning      IssuePlanning
-	Commitment    IssueCommitment
-	Design        string
-	QEAssignee    string
-	Acceptance    string
-	Owner         string
-	Impediment    bool
-	Comments      []*Comment
+	Link         string
+	ParentLink   string
+	Parents      IssueCollection
+	Initiative   *Issue
+	LinkedIssues IssueCollection
+	Subtasks     IssueCollection
+	StoryPoints  int
+	Gates        IssueGates
+	Design       string
+	QEAssignee   string
+	Acceptance   string
+	Owner        string
+	Impediment   bool
+	Comments     []*Comment
+	Transitions  []*StatusTransition
+	Workflow     Workflow `json:"-"`
 }
 
 // Comment represents Jira Issue Comment
@@ -67,8 +46,29 @@ var (
 	// ErrAuthentication is returned when the authentication failed
 	ErrAuthentication = errors.New("jira: access unauthorized")
 
-	// ErrMultipleIssues is returned when multiple issues are found
-	ErrMultipleIssues = errors.New("jira: unexpected multiple issues")
+	// ErrInvalidQuery is returned when a JQL query was rejected
+	ErrInvalidQuery = errors.New("jira: invalid query")
+
+	// ErrRateLimited is returned when the requests were throttled by the server
+	ErrRateLimited = errors.New("jira: too many requests")
+
+	// ErrUnknownField is returned when a field mapping refers to an unknown logical field
+	ErrUnknownField = errors.New("jira: unknown custom field")
+
+	// ErrFieldNotFound is returned when a mapped field cannot be found in the Jira instance
+	ErrFieldNotFound = errors.New("jira: field not found")
+
+	// ErrInvalidPrivateKey is returned when the OAuth private key cannot be parsed
+	ErrInvalidPrivateKey = errors.New("jira: invalid rsa private key")
+
+	// ErrInvalidStatusCategory is returned when a workflow status is mapped to an unknown category
+	ErrInvalidStatusCategory = errors.New("jira: invalid status category")
+
+	// ErrInvalidGate is returned when a gate of a rule is not in the "<set>.<option>" form
+	ErrInvalidGate = errors.New("jira: invalid gate")
+
+	// ErrInvalidLinksStrategy is returned when the links strategy is unknown
+	ErrInvalidLinksStrategy = errors.New("jira: invalid links strategy")
 )
 
 // IssueType represent an Issue Type
@@ -89,6 +89,12 @@ const (
 
 	// IssueTypeBug represents the Issue Type Bug
 	IssueTypeBug IssueType = "Bug"
+
+	// IssueTypeMarketProblem represents the Issue Type Market Problem
+	IssueTypeMarketProblem IssueType = "Market Problem"
+
+	// IssueTypeSubtask represents the Issue Type Sub-task
+	IssueTypeSubtask IssueType = "Sub-task"
 )
 
 // IssueStatus represent an Issue Status
@@ -135,27 +141,41 @@ func jiraReturnError(ret *jira.Response, err error) error {
 		return nil
 	}
 
-	if ret.Response.StatusCode == http.StatusForbidden || ret.Response.StatusCode == http.StatusUnauthorized {
+	if ret == nil || ret.Response == nil {
+		return err
+	}
+
+	switch ret.Response.StatusCode {
+	case http.StatusForbidden, http.StatusUnauthorized:
 		return ErrAuthentication
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", ErrInvalidQuery, err)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s", ErrRateLimited, err)
 	}
 
 	return err
 }
 
-// IsActive returns true if the issue is currently worked on
+// IsActive returns true if the issue is currently worked on (its status is in the active category)
 func (i *Issue) IsActive() bool {
-	switch IssueStatus(i.Fields.Status.Name) {
-	case IssueStatusInProgress:
-		return true
-	case IssueStatusFeatureComplete:
-		return true
-	case IssueStatusCodeReview:
-		return true
-	case IssueStatusQEReview:
-		return true
+	return i.StatusCategory() == StatusCategoryActive
+}
+
+// ParentKeys returns the keys of the issues linked to the issue with the relevant link description (e.g. "is child of")
+func (i *Issue) ParentKeys(link string) []string {
+	keys := []string{}
+
+	for _, l := range i.Fields.IssueLinks {
+		switch {
+		case l.OutwardIssue != nil && l.Type.Outward == link:
+			keys = append(keys, l.OutwardIssue.Key)
+		case l.InwardIssue != nil && l.Type.Inward == link:
+			keys = append(keys, l.InwardIssue.Key)
+		}
 	}
 
-	return false
+	return keys
 }
 
 // IsType returns true if the issue is of the relevant type
@@ -172,13 +192,9 @@ func (i *Issue) InStatus(status IssueStatus) bool {
 	return i.Fields.Status != nil && IssueStatus(i.Fields.Status.Name) == status
 }
 
-// IsResolved returns true if the issue Resolution is Done
+// IsResolved returns true if the issue status is in the done category
 func (i *Issue) IsResolved() bool {
-	if i.Fields.Resolution != nil && IssueResolution(i.Fields.Resolution.Name) == IssueResolutionDone {
-		return true
-	}
-
-	return false
+	return i.StatusCategory() == StatusCategoryDone
 }
 
 // IsPrioritized returns true if the issue Priority has been set
@@ -234,15 +250,12 @@ func (i *Issue) HasLabel(label string) bool {
 	return false
 }
 
-// Ready returns true if the issue is Ready-Ready
+// Ready returns true if the issue is Ready-Ready (see DefaultReadyRule)
 func (i *Issue) Ready() bool {
-	return (i.Readiness.Development && i.Readiness.Product && i.Readiness.Quality &&
-		i.Readiness.Experience && i.Readiness.Documentation && i.Readiness.Support)
+	return i.Satisfies(DefaultReadyRule)
 }
 
-// IsCommitted returns true if the issue has committment from all stakeholders
+// IsCommitted returns true if the issue has committment from all stakeholders (see DefaultCommittedRule)
 func (i *Issue) IsCommitted() bool {
-	return ((i.Planning.NoQuality || i.Commitment.Quality) &&
-		(i.Planning.NoDocumentation || i.Commitment.Documentation) &&
-		i.Commitment.Support)
+	return i.Satisfies(DefaultCommittedRule)
 }
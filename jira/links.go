@@ -0,0 +1,299 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ChildOfLink is the link description used to relate the issues to their parents
+	ChildOfLink = "is child of"
+
+	// ScriptRunnerField is the JQL field of the ScriptRunner functions (e.g. "issueFunction in issuesInEpics(...)")
+	ScriptRunnerField = "issueFunction"
+)
+
+// LinksStrategy represents how the parents and the stories of the epics are resolved
+type LinksStrategy string
+
+const (
+	// LinksStrategyAuto uses the ScriptRunner functions when they are available in the instance, plain JQL otherwise
+	LinksStrategyAuto LinksStrategy = "auto"
+
+	// LinksStrategyScriptRunner resolves the links with the ScriptRunner functions (linkedIssuesOfRecursive and issuesInEpics)
+	LinksStrategyScriptRunner LinksStrategy = "scriptrunner"
+
+	// LinksStrategyJQL resolves the links with plain JQL, walking the issue links, the Epic Link and the parent fields
+	LinksStrategyJQL LinksStrategy = "jql"
+)
+
+// ParseLinksStrategy returns the links strategy with the specified name, LinksStrategyAuto when empty
+func ParseLinksStrategy(name string) (LinksStrategy, error) {
+	switch s := LinksStrategy(name); s {
+	case "":
+		return LinksStrategyAuto, nil
+	case LinksStrategyAuto, LinksStrategyScriptRunner, LinksStrategyJQL:
+		return s, nil
+	}
+
+	return "", fmt.Errorf("%w: '%s'", ErrInvalidLinksStrategy, name)
+}
+
+// ParentLinks defines how the parents of the epics are resolved following their links
+type ParentLinks struct {
+	// Type is the issue type of the parents (e.g. "Market Problem", "Feature" or "Outcome")
+	Type string
+
+	// Link is the link description relating the issues to their parents (e.g. "is child of")
+	Link string
+
+	// Recursive follows the links through the ancestors of the epics, not only the direct parents
+	Recursive bool
+}
+
+// DefaultParentLinks resolves the market problems among all the ancestors of the epics
+var DefaultParentLinks = &ParentLinks{
+	Type:      string(IssueTypeMarketProblem),
+	Link:      ChildOfLink,
+	Recursive: true,
+}
+
+func addLinkedIssues(c *Client, epics IssueCollection, parentLinks *ParentLinks, strategy LinksStrategy) error {
+	findLinkedIssues := findLinkedIssuesScriptRunner
+
+	if strategy == LinksStrategyJQL {
+		findLinkedIssues = findLinkedIssuesJQL
+	}
+
+	parents, linkedIssues, err := findLinkedIssues(c, epics, parentLinks)
+
+	if err != nil {
+		return err
+	}
+
+	index := map[string]*Issue{}
+
+	for _, i := range append(parents, epics...) {
+		index[i.Key] = i
+	}
+
+	for _, i := range epics {
+		i.Parents = findAncestors(i, index, parentLinks.Link, parentLinks.Recursive).FilterByFunction(func(i *Issue) bool {
+			return i.IsType(IssueType(parentLinks.Type))
+		})
+
+		i.LinkedIssues = NewIssueCollection(0)
+	}
+
+	epicsIndex := map[string]*Issue{}
+
+	for _, i := range epics {
+		epicsIndex[i.Key] = i
+	}
+
+	for _, i := range linkedIssues {
+		if i.Fields.Epic != nil {
+			if e, ok := epicsIndex[i.Fields.Epic.Key]; ok {
+				e.LinkedIssues = append(e.LinkedIssues, i)
+				continue
+			}
+		}
+
+		if i.Fields.Parent != nil {
+			if e, ok := epicsIndex[i.Fields.Parent.Key]; ok {
+				e.LinkedIssues = append(e.LinkedIssues, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// findLinkedIssuesScriptRunner returns the parents and the stories of the epics using the ScriptRunner JQL functions
+func findLinkedIssuesScriptRunner(c *Client, epics IssueCollection, parentLinks *ParentLinks) (IssueCollection, IssueCollection, error) {
+	keys := strings.Join(epics.Keys(), ", ")
+
+	linkedIssuesFunction := "linkedIssuesOf"
+
+	if parentLinks.Recursive {
+		linkedIssuesFunction = "linkedIssuesOfRecursive"
+	}
+
+	jql := fmt.Sprintf("issueFunction in %s(\"key in (%s)\", \"%s\")", linkedIssuesFunction, keys, parentLinks.Link)
+	parents, err := c.FindIssues(jql)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jql = fmt.Sprintf("issueFunction in issuesInEpics(\"key in (%s)\")", keys)
+	stories, err := c.FindIssues(jql)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return parents, stories, nil
+}
+
+// findLinkedIssuesJQL returns the parents and the stories of the epics with plain JQL, the parents are found
+// walking the issue links level by level and the stories with the Epic Link (or the parent) of the epics
+func findLinkedIssuesJQL(c *Client, epics IssueCollection, parentLinks *ParentLinks) (IssueCollection, IssueCollection, error) {
+	parents := NewIssueCollection(0)
+	found := map[string]bool{}
+	pending := epics
+
+	for _, i := range epics {
+		found[i.Key] = true
+	}
+
+	for len(pending) > 0 {
+		children := pending.FilterByFunction(func(i *Issue) bool {
+			return len(i.ParentKeys(parentLinks.Link)) > 0
+		})
+
+		if len(children) == 0 {
+			break
+		}
+
+		linked := NewIssueCollection(0)
+
+		for _, b := range children.Chunks(c.BatchSize) {
+			clauses := make([]string, len(b))
+
+			for n, i := range b {
+				clauses[n] = fmt.Sprintf("issue in linkedIssues(\"%s\", \"%s\")", i.Key, parentLinks.Link)
+			}
+
+			issues, err := c.FindIssues(strings.Join(clauses, " OR "))
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			linked = append(linked, issues...)
+		}
+
+		pending = linked.FilterByFunction(func(i *Issue) bool {
+			if found[i.Key] {
+				return false
+			}
+
+			found[i.Key] = true
+
+			return true
+		})
+
+		parents = append(parents, pending...)
+
+		if !parentLinks.Recursive {
+			break
+		}
+	}
+
+	keys := strings.Join(epics.Keys(), ", ")
+	jql := fmt.Sprintf("parent in (%s)", keys)
+
+	if c.CustomFieldID.EpicLink != "" {
+		jql = fmt.Sprintf("%s in (%s) OR %s", jqlField(c.CustomFieldID.EpicLink), keys, jql)
+	}
+
+	stories, err := c.FindIssues(jql)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return parents, stories, nil
+}
+
+// jqlField returns the JQL clause name of the field ID (e.g. "cf[12310940]" for "customfield_12310940")
+func jqlField(id string) string {
+	if strings.HasPrefix(id, "customfield_") {
+		return fmt.Sprintf("cf[%s]", strings.TrimPrefix(id, "customfield_"))
+	}
+
+	return id
+}
+
+func findAncestors(i *Issue, index map[string]*Issue, link string, recursive bool) IssueCollection {
+	ancestors := NewIssueCollection(0)
+	visited := map[string]bool{i.Key: true}
+	queue := i.ParentKeys(link)
+
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+
+		if visited[key] {
+			continue
+		}
+
+		visited[key] = true
+
+		if p, ok := index[key]; ok {
+			ancestors = append(ancestors, p)
+
+			if recursive {
+				queue = append(queue, p.ParentKeys(link)...)
+			}
+		}
+	}
+
+	return ancestors
+}
+
+type jqlAutocompleteData struct {
+	VisibleFieldNames []struct {
+		Value string `json:"value"`
+	} `json:"visibleFieldNames"`
+}
+
+// hasScriptRunner returns true if the ScriptRunner JQL functions are available in the instance
+func (c *Client) hasScriptRunner() (bool, error) {
+	req, err := c.NewRequest("GET", "rest/api/2/jql/autocompletedata", nil)
+
+	if err != nil {
+		return false, err
+	}
+
+	data := jqlAutocompleteData{}
+	ret, err := c.Do(req, &data)
+
+	if err := jiraReturnError(ret, err); err != nil {
+		return false, err
+	}
+
+	for _, f := range data.VisibleFieldNames {
+		if f.Value == ScriptRunnerField {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ResolveLinksStrategy returns the strategy used to resolve the links of the epics, with LinksStrategyAuto
+// the availability of the ScriptRunner functions is detected once on first use, falling back to
+// LinksStrategyJQL (and reporting a warning, see Client.Warnings) when the detection fails
+func (c *Client) ResolveLinksStrategy() LinksStrategy {
+	c.linksOnce.Do(func() {
+		if c.Links != LinksStrategyAuto {
+			return
+		}
+
+		found, err := c.hasScriptRunner()
+
+		if err != nil {
+			c.addWarning(fmt.Errorf("ScriptRunner detection failed, links resolved with plain JQL: %w", err))
+		}
+
+		c.Links = LinksStrategyJQL
+
+		if found {
+			c.Links = LinksStrategyScriptRunner
+		}
+	})
+
+	return c.Links
+}
@@ -0,0 +1,96 @@
+package jira
+
+import (
+	"time"
+)
+
+// ProgressPoint represents the progress at the end of a day
+type ProgressPoint struct {
+	Date time.Time
+	Progress
+}
+
+// StatusAt returns the status of the issue at the specified time, ok is false when the issue didn't exist
+func (i *Issue) StatusAt(t time.Time) (string, bool) {
+	if time.Time(i.Fields.Created).After(t) {
+		return "", false
+	}
+
+	for _, s := range i.StatusIntervals(t) {
+		if !s.End.Before(t) {
+			return s.Status, true
+		}
+	}
+
+	return "", false
+}
+
+// IsResolvedAt returns true if the issue was resolved at the specified time, without the status history
+// the resolution date of the issue currently resolved is used
+func (i *Issue) IsResolvedAt(t time.Time) bool {
+	if !i.HasHistory() {
+		resolved, ok := i.ResolvedTime()
+		return ok && !time.Time(i.Fields.Created).After(t) && !resolved.After(t)
+	}
+
+	status, ok := i.StatusAt(t)
+
+	return ok && i.statusCategory(status) == StatusCategoryDone
+}
+
+// StoryPointsProgressAt returns the progress of the story points of the issues in the collection at the
+// specified time, the current story points of the issues are used
+func (c IssueCollection) StoryPointsProgressAt(t time.Time) Progress {
+	p := Progress{Total: 0, Status: 0, Unknown: 0}
+
+	for _, i := range c {
+		if !i.IsType(IssueTypeStory) || i.IsExcluded() || time.Time(i.Fields.Created).After(t) {
+			continue
+		}
+
+		if i.HasStoryPoints() {
+			p.Total = p.Total + i.StoryPoints
+
+			if i.IsResolvedAt(t) {
+				p.Status = p.Status + i.StoryPoints
+			}
+		} else {
+			p.Unknown = p.Unknown + 1
+		}
+	}
+
+	return p
+}
+
+// Created returns the creation time of the first issue created in the collection
+func (c IssueCollection) Created() (time.Time, bool) {
+	first := time.Time{}
+
+	for _, i := range c {
+		if created := time.Time(i.Fields.Created); first.IsZero() || created.Before(first) {
+			first = created
+		}
+	}
+
+	return first, !first.IsZero()
+}
+
+// StoryPointsSeries returns the daily progress of the story points of the issues in the collection
+// from the day of from to the day of to, each point is the progress at the end of the day
+func (c IssueCollection) StoryPointsSeries(from, to time.Time) []*ProgressPoint {
+	series := []*ProgressPoint{}
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+
+	for !day.After(to) {
+		end := day.AddDate(0, 0, 1)
+
+		if end.After(to) {
+			end = to
+		}
+
+		series = append(series, &ProgressPoint{day, c.StoryPointsProgressAt(end)})
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return series
+}
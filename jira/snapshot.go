@@ -0,0 +1,51 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SnapshotVersion is the version of the snapshot format
+const SnapshotVersion = 3
+
+// Snapshot represents the issues returned by a JQL search at a point in time
+type Snapshot struct {
+	Version  int
+	Created  time.Time
+	JQL      string
+	Issues   IssueCollection
+	Statuses Workflow `json:",omitempty"`
+}
+
+// NewSnapshot creates and returns a new Snapshot of the issues, with the status categories of the instance
+func NewSnapshot(jql string, issues IssueCollection, statuses Workflow) *Snapshot {
+	return &Snapshot{
+		Version:  SnapshotVersion,
+		Created:  time.Now(),
+		JQL:      jql,
+		Issues:   issues,
+		Statuses: statuses,
+	}
+}
+
+// WriteSnapshot writes the snapshot to the specified writer
+func WriteSnapshot(w io.Writer, s *Snapshot) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// ReadSnapshot reads a snapshot from the specified reader
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	s := &Snapshot{}
+
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, err
+	}
+
+	if s.Version != SnapshotVersion {
+		return nil, fmt.Errorf("jira: snapshot version %d not supported", s.Version)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,167 @@
+package jira
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the default number of retries of the throttled requests
+	DefaultMaxRetries = 5
+
+	// DefaultRetryBackoff is the default wait before the first retry, doubled on each attempt
+	DefaultRetryBackoff = 1 * time.Second
+
+	// MaxRetryBackoff is the maximum wait between retries
+	MaxRetryBackoff = 60 * time.Second
+)
+
+// RateLimiter is a token bucket limiting the rate of the requests, it can be
+// shared among multiple clients
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rate requests per second with
+// bursts of up to burst requests, a rate of zero disables the limit
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Wait blocks until a request is allowed or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+
+	now := time.Now()
+
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	l.last = now
+
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+
+	l.tokens--
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	return sleepContext(ctx, wait)
+}
+
+// RetryTransport is an http.RoundTripper that limits the rate of the requests
+// and retries the ones throttled by the server (honoring Retry-After)
+type RetryTransport struct {
+	Limiter    *RateLimiter
+	MaxRetries int
+	Backoff    time.Duration
+	Transport  http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.Backoff
+
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := t.Limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		r := req
+
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, err
+			}
+
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+
+		resp, err := t.transport().RoundTrip(r)
+
+		if err != nil || !retryStatus(resp.StatusCode) || attempt >= t.MaxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		wait := retryAfter(resp, backoff<<uint(attempt))
+		resp.Body.Close()
+
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *RetryTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+
+	return http.DefaultTransport
+}
+
+func retryStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+
+	return false
+}
+
+func retryAfter(resp *http.Response, backoff time.Duration) time.Duration {
+	wait := backoff + time.Duration(rand.Int63n(int64(backoff/2)+1))
+
+	if h := resp.Header.Get("Retry-After"); h != "" {
+		if s, err := strconv.Atoi(h); err == nil {
+			wait = time.Duration(s) * time.Second
+		} else if t, err := http.ParseTime(h); err == nil {
+			wait = time.Until(t)
+		}
+	}
+
+	if wait > MaxRetryBackoff {
+		wait = MaxRetryBackoff
+	}
+
+	return wait
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
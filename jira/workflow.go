@@ -0,0 +1,181 @@
+package jira
+
+import (
+	"fmt"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// StatusCategory represents the category of a workflow status
+type StatusCategory string
+
+const (
+	// StatusCategoryTodo is the category of the statuses where the work is not started
+	StatusCategoryTodo StatusCategory = "todo"
+
+	// StatusCategoryActive is the category of the statuses where the issue is worked on
+	StatusCategoryActive StatusCategory = "active"
+
+	// StatusCategoryDone is the category of the statuses where the issue is resolved
+	StatusCategoryDone StatusCategory = "done"
+
+	// StatusCategoryExcluded is the category of the statuses ignored by the progress (e.g. "Obsolete")
+	StatusCategoryExcluded StatusCategory = "excluded"
+)
+
+// StatusCategories are the valid status categories
+var StatusCategories = []StatusCategory{
+	StatusCategoryTodo, StatusCategoryActive, StatusCategoryDone, StatusCategoryExcluded,
+}
+
+// jiraStatusCategories maps the keys of the Jira status categories
+var jiraStatusCategories = map[string]StatusCategory{
+	"new":           StatusCategoryTodo,
+	"indeterminate": StatusCategoryActive,
+	"done":          StatusCategoryDone,
+}
+
+// Workflow maps the workflow statuses to their categories, the statuses not
+// mapped fall back to the Jira status category
+type Workflow map[string]StatusCategory
+
+// DefaultWorkflow is the workflow used when none is set on the issues
+var DefaultWorkflow = Workflow{
+	string(IssueStatusObsolete): StatusCategoryExcluded,
+}
+
+// newStatusesWorkflow returns the workflow mapping the statuses of the instance to their Jira status category
+func newStatusesWorkflow(statuses []jira.Status) Workflow {
+	w := Workflow{}
+
+	for _, s := range statuses {
+		if c, ok := jiraStatusCategories[s.StatusCategory.Key]; ok {
+			w[s.Name] = c
+		}
+	}
+
+	return w
+}
+
+// NewWorkflow creates and returns a Workflow from the status names and the category names
+func NewWorkflow(statuses map[string]string) (Workflow, error) {
+	w := Workflow{}
+
+	for status, category := range statuses {
+		c, err := ParseStatusCategory(category)
+
+		if err != nil {
+			return nil, fmt.Errorf("status '%s': %w", status, err)
+		}
+
+		w[status] = c
+	}
+
+	return w, nil
+}
+
+// ParseStatusCategory returns the status category with the specified name
+func ParseStatusCategory(name string) (StatusCategory, error) {
+	for _, c := range StatusCategories {
+		if string(c) == name {
+			return c, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: '%s'", ErrInvalidStatusCategory, name)
+}
+
+// Merge returns a workflow with the statuses of both workflows, the statuses of other take precedence
+func (w Workflow) Merge(other Workflow) Workflow {
+	merged := Workflow{}
+
+	for k, v := range w {
+		merged[k] = v
+	}
+
+	for k, v := range other {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// Category returns the category of the status name, ok is false when the status is not known
+func (w Workflow) Category(status string) (StatusCategory, bool) {
+	c, ok := w[status]
+	return c, ok
+}
+
+// StatusCategory returns the category of the issue current status
+func (i *Issue) StatusCategory() StatusCategory {
+	if i.Fields.Status == nil {
+		return StatusCategoryTodo
+	}
+
+	if c, ok := i.workflow().Category(i.Fields.Status.Name); ok {
+		return c
+	}
+
+	if c, ok := jiraStatusCategories[i.Fields.Status.StatusCategory.Key]; ok {
+		return c
+	}
+
+	return StatusCategoryTodo
+}
+
+func (i *Issue) workflow() Workflow {
+	if i.Workflow == nil {
+		return DefaultWorkflow
+	}
+
+	return i.Workflow
+}
+
+// IsExcluded returns true if the issue status is excluded from the progress
+func (i *Issue) IsExcluded() bool {
+	return i.StatusCategory() == StatusCategoryExcluded
+}
+
+// SetWorkflow sets the workflow of the issues and of all the related issues (stories, sub-tasks,
+// parents and initiatives). The statuses not mapped by the workflow are mapped to their category in
+// the instance (see Client.Statuses, it can be nil), or to the Jira category of the issues found in
+// such status, so that the statuses only found in the changelog are categorized as well.
+func (c IssueCollection) SetWorkflow(w Workflow, statuses Workflow) {
+	issues := c.flatten(nil, map[*Issue]bool{})
+	configured := DefaultWorkflow.Merge(w)
+	observed := Workflow{}
+
+	for _, i := range issues {
+		i.Workflow = configured
+
+		if i.Fields.Status != nil {
+			observed[i.Fields.Status.Name] = i.StatusCategory()
+		}
+	}
+
+	workflow := observed.Merge(statuses).Merge(configured)
+
+	for _, i := range issues {
+		i.Workflow = workflow
+	}
+}
+
+func (c IssueCollection) flatten(issues []*Issue, found map[*Issue]bool) []*Issue {
+	for _, i := range c {
+		if i == nil || found[i] {
+			continue
+		}
+
+		found[i] = true
+		issues = append(issues, i)
+		issues = i.LinkedIssues.flatten(issues, found)
+		issues = i.Subtasks.flatten(issues, found)
+		issues = i.Parents.flatten(issues, found)
+
+		if i.Initiative != nil {
+			issues = IssueCollection{i.Initiative}.flatten(issues, found)
+		}
+	}
+
+	return issues
+}
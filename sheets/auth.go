@@ -0,0 +1,195 @@
+package sheets
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Scope is the OAuth scope required to read and write spreadsheets
+	Scope = "https://www.googleapis.com/auth/spreadsheets"
+
+	// DefaultTokenURI is the Google OAuth token endpoint
+	DefaultTokenURI = "https://oauth2.googleapis.com/token"
+)
+
+var (
+	// ErrInvalidKey is returned when the service account key cannot be used
+	ErrInvalidKey = errors.New("sheets: invalid service account key")
+)
+
+// ServiceAccountKey represents a Google service account JSON key
+type ServiceAccountKey struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// ReadServiceAccountKey reads a service account JSON key from the specified path
+func ReadServiceAccountKey(path string) (*ServiceAccountKey, error) {
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	k := &ServiceAccountKey{}
+
+	if err := json.Unmarshal(data, k); err != nil {
+		return nil, err
+	}
+
+	if k.ClientEmail == "" || k.PrivateKey == "" {
+		return nil, ErrInvalidKey
+	}
+
+	if k.TokenURI == "" {
+		k.TokenURI = DefaultTokenURI
+	}
+
+	return k, nil
+}
+
+// ServiceAccountTransport is an http.RoundTripper that authenticates all the
+// requests with an access token obtained through the service account key
+type ServiceAccountTransport struct {
+	Key       *ServiceAccountKey
+	Scopes    []string
+	Transport http.RoundTripper
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *ServiceAccountTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.accessToken()
+
+	if err != nil {
+		return nil, err
+	}
+
+	req2 := req.Clone(req.Context())
+	req2.Header.Set("Authorization", "Bearer "+token)
+
+	return t.transport().RoundTrip(req2)
+}
+
+func (t *ServiceAccountTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+
+	return http.DefaultTransport
+}
+
+func (t *ServiceAccountTransport) accessToken() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Before(t.expiry) {
+		return t.token, nil
+	}
+
+	assertion, err := t.assertion(time.Now())
+
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+
+	resp, err := (&http.Client{Transport: t.transport()}).PostForm(t.Key.TokenURI, form)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError(resp)
+	}
+
+	token := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+
+	t.token = token.AccessToken
+	t.expiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+
+	return t.token, nil
+}
+
+func (t *ServiceAccountTransport) assertion(now time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(t.Key.PrivateKey))
+
+	if block == nil {
+		return "", ErrInvalidKey
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidKey, err)
+	}
+
+	key, ok := parsedKey.(*rsa.PrivateKey)
+
+	if !ok {
+		return "", ErrInvalidKey
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": t.Key.PrivateKeyID})
+
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   t.Key.ClientEmail,
+		"scope": strings.Join(t.Scopes, " "),
+		"aud":   t.Key.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(payload))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+
+	if err != nil {
+		return "", err
+	}
+
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,194 @@
+package sheets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL is the base URL of the Google Sheets v4 API
+const DefaultBaseURL = "https://sheets.googleapis.com/v4/"
+
+// Client represents a minimal Google Sheets v4 API client
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates and returns a new Client authenticated with the service account key
+func NewClient(key *ServiceAccountKey) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{Transport: &ServiceAccountTransport{Key: key, Scopes: []string{Scope}}},
+	}
+}
+
+// APIError represents an error returned by the Google Sheets API
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("sheets: %d %s", e.StatusCode, e.Message)
+}
+
+// Range returns the A1 notation of the range starting at cell in the sheet
+// tab (the whole sheet when cell is empty)
+func Range(tab, cell string) string {
+	r := "'" + strings.ReplaceAll(tab, "'", "''") + "'"
+
+	if cell != "" {
+		r += "!" + cell
+	}
+
+	return r
+}
+
+// SheetID returns the ID of the sheet tab with the specified title
+func (c *Client) SheetID(spreadsheet, title string) (int64, error) {
+	reply := struct {
+		Sheets []struct {
+			Properties struct {
+				SheetID int64  `json:"sheetId"`
+				Title   string `json:"title"`
+			} `json:"properties"`
+		} `json:"sheets"`
+	}{}
+
+	path := "spreadsheets/" + url.PathEscape(spreadsheet) + "?fields=sheets.properties"
+
+	if err := c.do(http.MethodGet, path, nil, &reply); err != nil {
+		return 0, err
+	}
+
+	for _, s := range reply.Sheets {
+		if s.Properties.Title == title {
+			return s.Properties.SheetID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("sheets: tab '%s' not found in spreadsheet %s", title, spreadsheet)
+}
+
+// Clear clears the values in the range of the spreadsheet
+func (c *Client) Clear(spreadsheet, rng string) error {
+	path := "spreadsheets/" + url.PathEscape(spreadsheet) + "/values/" + url.PathEscape(rng) + ":clear"
+
+	return c.do(http.MethodPost, path, struct{}{}, nil)
+}
+
+// Update writes the values to the range of the spreadsheet as if typed by a
+// user (i.e. formulas are evaluated)
+func (c *Client) Update(spreadsheet, rng string, values [][]interface{}) error {
+	path := "spreadsheets/" + url.PathEscape(spreadsheet) + "/values/" + url.PathEscape(rng) + "?valueInputOption=USER_ENTERED"
+
+	body := map[string]interface{}{
+		"range":          rng,
+		"majorDimension": "ROWS",
+		"values":         values,
+	}
+
+	return c.do(http.MethodPut, path, body, nil)
+}
+
+// BatchUpdate applies the requests (e.g. formatting) to the spreadsheet
+func (c *Client) BatchUpdate(spreadsheet string, requests []interface{}) error {
+	path := "spreadsheets/" + url.PathEscape(spreadsheet) + ":batchUpdate"
+
+	return c.do(http.MethodPost, path, map[string]interface{}{"requests": requests}, nil)
+}
+
+// BoldRowsRequest returns a batch update request setting the text of the rows
+// in [start, end) of the sheet as bold (or not)
+func BoldRowsRequest(sheetID int64, start, end int, bold bool) interface{} {
+	rowRange := map[string]interface{}{"sheetId": sheetID}
+
+	if start >= 0 && end > start {
+		rowRange["startRowIndex"] = start
+		rowRange["endRowIndex"] = end
+	}
+
+	return map[string]interface{}{
+		"repeatCell": map[string]interface{}{
+			"range": rowRange,
+			"cell": map[string]interface{}{
+				"userEnteredFormat": map[string]interface{}{
+					"textFormat": map[string]interface{}{"bold": bold},
+				},
+			},
+			"fields": "userEnteredFormat.textFormat.bold",
+		},
+	}
+}
+
+func (c *Client) do(method, path string, body, reply interface{}) error {
+	var r io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+
+		if err != nil {
+			return err
+		}
+
+		r = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.BaseURL, "/")+"/"+path, r)
+
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return responseError(resp)
+	}
+
+	if reply == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(reply)
+}
+
+func responseError(resp *http.Response) error {
+	data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	reply := struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+
+	message := strings.TrimSpace(string(data))
+
+	if json.Unmarshal(data, &reply) == nil {
+		switch {
+		case reply.Error.Message != "":
+			message = reply.Error.Message
+		case reply.ErrorDescription != "":
+			message = reply.ErrorDescription
+		}
+	}
+
+	return &APIError{resp.StatusCode, message}
+}